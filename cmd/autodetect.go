@@ -7,11 +7,22 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var (
+	autodetectRecursive bool
+	autodetectRef       string
+)
+
 var autodetectCmd = &cobra.Command{
 	Use:   "autodetect [path]",
 	Short: "Detect project information",
-	Long:  `Scan a directory and detect programming languages, frameworks, and Docker configuration`,
-	Args:  cobra.MaximumNArgs(1),
+	Long: `Scan a directory and detect programming languages, frameworks, and Docker configuration.
+
+With --recursive, the whole tree is scanned (skipping vendor, node_modules and .git)
+and results are grouped by sub-project, which is useful for monorepos.
+
+With --ref, files are read from the given git revision (branch, tag, remote
+branch or commit) of the local repository instead of the working tree.`,
+	Args: cobra.MaximumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		// Determine target directory
 		targetDir := "."
@@ -19,18 +30,33 @@ var autodetectCmd = &cobra.Command{
 			targetDir = args[0]
 		}
 
-		fmt.Printf("Scanning directory: %s\n", targetDir)
-
-		// Execute detection in target directory
-		err := helpers.WithWorkingDir(targetDir, func() error {
-			// Detect project information
-			info := DetectProjectInfo()
-			PrintDetectedInfo(info)
-			return nil
-		})
+		if autodetectRef != "" {
+			fmt.Printf("Scanning directory: %s at %s\n", targetDir, autodetectRef)
+		} else {
+			fmt.Printf("Scanning directory: %s\n", targetDir)
+		}
 
+		fsys, err := openProjectFS(targetDir, autodetectRef)
 		if err != nil {
 			helpers.ExitOnError(err, "failed to scan directory")
 		}
+
+		if autodetectRecursive {
+			projects, err := DetectSubProjects(fsys)
+			if err != nil {
+				helpers.ExitOnError(err, "failed to scan directory")
+			}
+			PrintSubProjects(projects)
+			return
+		}
+
+		// Detect project information
+		info := DetectProjectInfo(fsys)
+		PrintDetectedInfo(info)
 	},
 }
+
+func init() {
+	autodetectCmd.Flags().BoolVarP(&autodetectRecursive, "recursive", "r", false, "Scan sub-directories and group results by sub-project")
+	autodetectCmd.Flags().StringVar(&autodetectRef, "ref", "", "Detect from a git revision instead of the working tree")
+}
@@ -0,0 +1,281 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/stacktodate/stacktodate-cli/cmd/helpers"
+	"github.com/stacktodate/stacktodate-cli/cmd/lib/cache"
+	"github.com/spf13/cobra"
+)
+
+// CatalogProduct is a product in catalog list output
+type CatalogProduct struct {
+	Key      string `json:"key"`
+	Name     string `json:"name"`
+	Releases int    `json:"releases"`
+	Latest   string `json:"latest,omitempty"` // Latest release cycle
+	Overlay  bool   `json:"overlay,omitempty"` // Added by the local catalog overlay
+}
+
+// CatalogProductDetail is a product and its release cycles in catalog show output
+type CatalogProductDetail struct {
+	Key      string           `json:"key"`
+	Name     string           `json:"name"`
+	Releases []CatalogRelease `json:"releases"`
+}
+
+// CatalogRelease is a release cycle with its support status relative to today
+type CatalogRelease struct {
+	Cycle         string `json:"cycle"`
+	ReleaseDate   string `json:"release_date,omitempty"`
+	LTS           bool   `json:"lts"`
+	Support       string `json:"support,omitempty"`
+	EOL           string `json:"eol,omitempty"`
+	Extended      string `json:"extended,omitempty"`
+	Status        string `json:"status"`                   // "active", "security", "extended" or "eol"
+	DaysRemaining *int   `json:"days_remaining,omitempty"` // Until EOL, negative once past it
+	Overlay       bool   `json:"overlay,omitempty"`        // Added or changed by the local catalog overlay
+}
+
+var catalogFormat string
+
+var catalogCmd = &cobra.Command{
+	Use:   "catalog",
+	Short: "Manage the local product catalog",
+	Long: `Manage the product catalog that detection and EOL checks use.
+
+For machines without internet access, export the catalog on a connected machine and
+import it on the offline one, or check the snapshot into the repository and point
+STD_CATALOG_FILE at it to use it without any network calls:
+
+  stacktodate fetch-catalog && stacktodate catalog export catalog.json
+  STD_CATALOG_FILE=catalog.json stacktodate check --fail-on eol`,
+}
+
+var catalogListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the products in the catalog",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		products, err := cache.GetProducts()
+		if err != nil {
+			helpers.ExitOnError(err, "failed to load catalog")
+		}
+
+		list := make([]CatalogProduct, 0, len(products))
+		for _, product := range products {
+			latest, _ := latestRelease(product.Releases, false, 0)
+			list = append(list, CatalogProduct{
+				Key:      product.Key,
+				Name:     product.Name,
+				Releases: len(product.Releases),
+				Latest:   latest,
+				Overlay:  product.Overlay,
+			})
+		}
+		sort.Slice(list, func(i, j int) bool { return list[i].Key < list[j].Key })
+
+		if catalogFormat == "json" {
+			outputCatalogJSON(os.Stdout, list)
+			return
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "KEY\tNAME\tRELEASES\tLATEST")
+		overlay := false
+		for _, product := range list {
+			name := product.Name
+			if product.Overlay {
+				name += " *"
+				overlay = true
+			}
+			fmt.Fprintf(w, "%s\t%s\t%d\t%s\n", product.Key, name, product.Releases, product.Latest)
+		}
+		w.Flush()
+		if overlay {
+			fmt.Println("\n* from the local catalog overlay")
+		}
+	},
+}
+
+var catalogShowCmd = &cobra.Command{
+	Use:   "show <product>",
+	Short: "Show the release cycles and EOL dates of a product",
+	Long: `Show the release cycles of a product in the catalog, with their release date, LTS flag,
+end of support and end of life dates, and their status as of today:
+
+  active    still actively supported
+  security  past active support, receiving security fixes only
+  extended  past end of life, within extended support
+  eol       past end of life`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		products, err := cache.GetProducts()
+		if err != nil {
+			helpers.ExitOnError(err, "failed to load catalog")
+		}
+
+		product := cache.GetProductByKey(args[0], products)
+		if product == nil {
+			helpers.ExitWithError(1, "unknown product %q (run 'stacktodate catalog list' to see all products)", args[0])
+		}
+
+		detail := describeProduct(product, time.Now())
+		if catalogFormat == "json" {
+			outputCatalogJSON(os.Stdout, detail)
+			return
+		}
+		outputProductText(os.Stdout, detail)
+	},
+}
+
+var catalogExportCmd = &cobra.Command{
+	Use:   "export <file>",
+	Short: "Write the cached product catalog to a snapshot file",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		snapshot, err := cache.ExportSnapshot(args[0])
+		if err != nil {
+			helpers.ExitOnError(err, "failed to export catalog (run 'stacktodate fetch-catalog' first)")
+		}
+
+		fmt.Fprintf(os.Stderr, "✓ Exported %d products to %s\n", len(snapshot.Products), args[0])
+		fmt.Fprintf(os.Stderr, "Snapshot date: %s\n", snapshot.SnapshotDate.Format("2006-01-02"))
+	},
+}
+
+var catalogImportCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Replace the cached product catalog with a snapshot file",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		snapshot, err := cache.ImportSnapshot(args[0])
+		if err != nil {
+			helpers.ExitOnError(err, "failed to import catalog")
+		}
+
+		cachePath, _ := cache.GetCachePath()
+		fmt.Fprintf(os.Stderr, "✓ Imported %d products from %s\n", len(snapshot.Products), args[0])
+		fmt.Fprintf(os.Stderr, "Snapshot date: %s\n", snapshot.SnapshotDate.Format("2006-01-02"))
+		fmt.Fprintf(os.Stderr, "Cache location: %s\n", cachePath)
+	},
+}
+
+// describeProduct returns the release cycles of a product with their status at the given time
+func describeProduct(product *cache.Product, now time.Time) CatalogProductDetail {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	detail := CatalogProductDetail{Key: product.Key, Name: product.Name, Releases: []CatalogRelease{}}
+	for _, release := range product.Releases {
+		entry := CatalogRelease{
+			Cycle:       release.ReleaseCycle,
+			ReleaseDate: release.ReleaseDate,
+			LTS:         release.LTS,
+			Support:     release.Support,
+			EOL:         release.EOL,
+			Extended:    release.Extended,
+			Status:      releaseStatus(release, today),
+			Overlay:     release.Overlay,
+		}
+		if date, ok := release.EOLDate(); ok {
+			days := int(date.Sub(today).Hours() / 24)
+			entry.DaysRemaining = &days
+		}
+		detail.Releases = append(detail.Releases, entry)
+	}
+
+	return detail
+}
+
+// releaseStatus returns whether a release is actively supported, receiving security fixes
+// only, within extended support or past its end of life on the given day
+func releaseStatus(release cache.Release, today time.Time) string {
+	if release.IsEOL(today) {
+		if date, ok := release.ExtendedDate(); ok && today.Before(date) {
+			return "extended"
+		}
+		return "eol"
+	}
+	if date, ok := release.SupportDate(); ok && !today.Before(date) {
+		return "security"
+	}
+	return "active"
+}
+
+func outputProductText(out io.Writer, detail CatalogProductDetail) {
+	fmt.Fprintf(out, "%s (%s)\n\n", detail.Name, detail.Key)
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "CYCLE\tRELEASED\tLTS\tSUPPORT\tEOL\tSTATUS")
+	overlay := false
+	for _, release := range detail.Releases {
+		lts := "no"
+		if release.LTS {
+			lts = "yes"
+		}
+		cycle := release.Cycle
+		if release.Overlay {
+			cycle += " *"
+			overlay = true
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			cycle,
+			orDash(release.ReleaseDate),
+			lts,
+			orDash(release.Support),
+			orDash(release.EOL),
+			describeReleaseStatus(release))
+	}
+	w.Flush()
+
+	if overlay {
+		fmt.Fprintln(out, "\n* from the local catalog overlay")
+	}
+}
+
+// describeReleaseStatus formats the status of a release with the days until or since its EOL
+func describeReleaseStatus(release CatalogRelease) string {
+	if release.DaysRemaining == nil {
+		return release.Status
+	}
+
+	days := *release.DaysRemaining
+	switch {
+	case days < 0:
+		return fmt.Sprintf("%s (EOL %d days ago)", release.Status, -days)
+	case days == 0:
+		return fmt.Sprintf("%s (EOL today)", release.Status)
+	}
+	return fmt.Sprintf("%s (EOL in %d days)", release.Status, days)
+}
+
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
+
+func outputCatalogJSON(w io.Writer, v interface{}) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		helpers.ExitOnError(err, "failed to marshal JSON")
+	}
+	fmt.Fprintln(w, string(data))
+}
+
+func init() {
+	rootCmd.AddCommand(catalogCmd)
+	catalogCmd.AddCommand(catalogListCmd)
+	catalogCmd.AddCommand(catalogShowCmd)
+	catalogCmd.AddCommand(catalogExportCmd)
+	catalogCmd.AddCommand(catalogImportCmd)
+	catalogListCmd.Flags().StringVarP(&catalogFormat, "format", "f", "text", "Output format: text or json (default: text)")
+	catalogShowCmd.Flags().StringVarP(&catalogFormat, "format", "f", "text", "Output format: text or json (default: text)")
+}
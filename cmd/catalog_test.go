@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stacktodate/stacktodate-cli/cmd/lib/cache"
+)
+
+func TestReleaseStatus(t *testing.T) {
+	today := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		release  cache.Release
+		expected string
+	}{
+		{"supported", cache.Release{Support: "2027-04-01", EOL: "2028-10-31"}, "active"},
+		{"no dates", cache.Release{}, "active"},
+		{"security fixes only", cache.Release{Support: "2026-04-01", EOL: "2027-10-31"}, "security"},
+		{"past eol", cache.Release{Support: "2024-04-01", EOL: "2026-10-16"}, "eol"},
+		{"eol flag", cache.Release{EOL: "true"}, "eol"},
+		{"extended support", cache.Release{EOL: "2026-01-01", Extended: "2029-01-01"}, "extended"},
+		{"extended support over", cache.Release{EOL: "2024-01-01", Extended: "2026-01-01"}, "eol"},
+	}
+
+	for _, tt := range tests {
+		if status := releaseStatus(tt.release, today); status != tt.expected {
+			t.Errorf("releaseStatus(%s) = %q, want %q", tt.name, status, tt.expected)
+		}
+	}
+}
+
+func TestDescribeProduct(t *testing.T) {
+	product := &cache.Product{
+		Key:  "python",
+		Name: "Python",
+		Releases: []cache.Release{
+			{ReleaseCycle: "3.13", ReleaseDate: "2024-10-07", Support: "2026-10-01", EOL: "2029-10-31"},
+			{ReleaseCycle: "3.10", ReleaseDate: "2021-10-04", Support: "2023-04-05", EOL: "2026-10-31"},
+			{ReleaseCycle: "3.8", ReleaseDate: "2019-10-14", Support: "2021-05-03", EOL: "2024-10-07"},
+		},
+	}
+
+	detail := describeProduct(product, time.Date(2026, 10, 16, 15, 0, 0, 0, time.UTC))
+
+	expected := []struct {
+		status      string
+		days        int
+		description string
+	}{
+		{"security", 1111, "security (EOL in 1111 days)"},
+		{"security", 15, "security (EOL in 15 days)"},
+		{"eol", -739, "eol (EOL 739 days ago)"},
+	}
+	for i, e := range expected {
+		release := detail.Releases[i]
+		if release.Status != e.status || release.DaysRemaining == nil || *release.DaysRemaining != e.days {
+			t.Errorf("describeProduct: release %s = %+v, want status %s and %d days", release.Cycle, release, e.status, e.days)
+			continue
+		}
+		if description := describeReleaseStatus(release); description != e.description {
+			t.Errorf("describeReleaseStatus(%s) = %q, want %q", release.Cycle, description, e.description)
+		}
+	}
+}
@@ -3,9 +3,16 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"time"
 
 	"github.com/stacktodate/stacktodate-cli/cmd/helpers"
+	"github.com/stacktodate/stacktodate-cli/cmd/lib/cache"
+	"github.com/stacktodate/stacktodate-cli/cmd/lib/versions"
 	"github.com/spf13/cobra"
 )
 
@@ -13,18 +20,34 @@ type CheckResult struct {
 	Status  string                 `json:"status"`
 	Summary CheckSummary           `json:"summary"`
 	Results CheckResults           `json:"results"`
+	Catalog *CatalogInfo           `json:"catalog,omitempty"` // Set when EOL data comes from a catalog snapshot
 }
 
+// CatalogInfo describes the catalog snapshot that EOL data was read from
+type CatalogInfo struct {
+	SnapshotDate string `json:"snapshot_date"`
+	AgeDays      int    `json:"age_days"`
+	Stale        bool   `json:"stale"` // Older than staleCatalogDays
+}
+
+// staleCatalogDays is the age after which a catalog snapshot is reported as stale
+const staleCatalogDays = 30
+
 type CheckSummary struct {
 	Matches       int `json:"matches"`
 	Mismatches    int `json:"mismatches"`
 	MissingConfig int `json:"missing_config"`
+	Untracked     int `json:"untracked"`
+	EOL           int `json:"eol"`
+	EOLSoon       int `json:"eol_soon"`
 }
 
 type CheckResults struct {
 	Matched       []ComparisonEntry `json:"matched"`
 	Mismatched    []ComparisonEntry `json:"mismatched"`
 	MissingConfig []ComparisonEntry `json:"missing_config"`
+	Untracked     []ComparisonEntry `json:"untracked"` // Detected but not listed in stacktodate.yml
+	EOL           []EOLEntry        `json:"eol"`
 }
 
 type ComparisonEntry struct {
@@ -32,17 +55,80 @@ type ComparisonEntry struct {
 	Version  string `json:"version,omitempty"`
 	Detected string `json:"detected,omitempty"`
 	Source   string `json:"source,omitempty"`
+	Line     int    `json:"line,omitempty"` // Line in Source, or in stacktodate.yml for entries missing from detection
+	RuleID   string `json:"rule_id"`
+	Severity string `json:"severity"`
+}
+
+// EOLEntry is a tracked component that is past its end of life or within the warning window
+type EOLEntry struct {
+	Name          string `json:"name"`
+	Version       string `json:"version"`
+	Status        string `json:"status"` // "eol" or "eol_soon"
+	EOLDate       string `json:"eol_date,omitempty"`
+	DaysRemaining *int   `json:"days_remaining,omitempty"` // Negative once past EOL
+	Line          int    `json:"line,omitempty"`           // Line in stacktodate.yml
+	Overlay       bool   `json:"overlay,omitempty"`        // The release data comes from the local catalog overlay
+	RuleID        string `json:"rule_id"`
+	Severity      string `json:"severity"`
+}
+
+// Rule IDs identify the kind of each check entry, e.g. in SARIF reports
+const (
+	ruleVersionMatch    = "version-match"
+	ruleVersionMismatch = "version-mismatch"
+	ruleMissing         = "missing-from-detection"
+	ruleUntracked       = "untracked"
+	ruleEOL             = "end-of-life"
+	ruleEOLSoon         = "end-of-life-soon"
+)
+
+// Severities use the SARIF result levels
+const (
+	severityError   = "error"
+	severityWarning = "warning"
+	severityNone    = "none"
+)
+
+// checkPolicy is the policy after merging command-line flags over the config's policy section
+type checkPolicy struct {
+	FailOnEOL       bool
+	FailOnEOLSoon   bool
+	FailOnUntracked bool
+	WarnDays        int
+}
+
+// NeedsEOLData reports whether check needs to look at EOL data at all
+func (p checkPolicy) NeedsEOLData() bool {
+	return p.FailOnEOL || p.FailOnEOLSoon || p.WarnDays > 0
 }
 
 var (
 	checkConfigFile string
 	checkFormat     string
+	checkRef        string
+	checkFailOn     []string
+	checkWarnWithin string
+	checkOutput     string
 )
 
 var checkCmd = &cobra.Command{
 	Use:   "check",
 	Short: "Check if detected versions match stacktodate.yml",
-	Long:  `Verify that the versions in stacktodate.yml match the currently detected versions in your project. Useful for CI/CD pipelines.`,
+	Long: `Verify that the versions in stacktodate.yml match the currently detected versions in your project. Useful for CI/CD pipelines.
+
+With --ref, versions are detected from the given git revision of the local repository
+instead of the working tree, while stacktodate.yml is still read from disk.
+
+With --fail-on eol, the check also fails when a tracked component is past its end of life.
+--warn-within 90d reports components reaching EOL within that window, and --fail-on eol-soon
+fails on them too. Technologies detected but not listed in stacktodate.yml are reported
+as untracked, and --fail-on untracked fails on them. The policy can also be set in the
+policy section of stacktodate.yml:
+
+  policy:
+    fail_on: [eol]
+    warn_within: 90d`,
 	Run: func(cmd *cobra.Command, args []string) {
 		// Use default config file if not specified
 		if checkConfigFile == "" {
@@ -55,6 +141,11 @@ var checkCmd = &cobra.Command{
 			helpers.ExitWithError(2, "failed to load config: %v", err)
 		}
 
+		policy, err := resolvePolicy(config.Policy, checkFailOn, checkWarnWithin)
+		if err != nil {
+			helpers.ExitWithError(2, "invalid policy: %v", err)
+		}
+
 		// Resolve absolute path for directory management
 		absConfigPath, err := helpers.ResolveAbsPath(checkConfigFile)
 		if err != nil {
@@ -67,25 +158,66 @@ var checkCmd = &cobra.Command{
 			helpers.ExitOnError(err, "failed to get config directory")
 		}
 
-		// Detect current versions in config directory
-		var detectedStack map[string]helpers.StackEntry
-		err = helpers.WithWorkingDir(configDir, func() error {
-			detectedInfo := DetectProjectInfo()
-			detectedStack = normalizeDetectedToStack(detectedInfo)
-			return nil
-		})
+		// Detect from the working tree, or from a git revision when --ref is set
+		fsys, err := openProjectFS(configDir, checkRef)
 		if err != nil {
 			helpers.ExitOnError(err, "failed to detect versions")
 		}
 
-		// Compare stacks
-		result := compareStacks(config.Stack, detectedStack)
+		// Detect current versions in config directory and of every sub-project listed in the config
+		rootInfo, projectInfos, err := DetectConfigProjects(fsys, config.Projects)
+		if err != nil {
+			helpers.ExitOnError(err, "failed to detect versions")
+		}
+		detectedStack := normalizeDetectedToStack(rootInfo)
+		detectedProjects := make(map[string]helpers.ProjectConfig)
+		for path, info := range projectInfos {
+			detectedProjects[path] = helpers.ProjectConfig{Stack: normalizeDetectedToStack(info)}
+		}
 
-		// Output results
-		if checkFormat == "json" {
-			outputJSON(result)
+		// Compare stacks
+		configStack := flattenProjectStacks(config.Stack, config.Projects)
+		result := compareStacks(configStack, flattenProjectStacks(detectedStack, detectedProjects))
+
+		// Evaluate the EOL policy against the tracked versions
+		var eolEntries []EOLEntry
+		if policy.NeedsEOLData() {
+			products, err := cache.GetProducts()
+			if err != nil {
+				helpers.ExitWithError(2, "failed to load EOL data: %v", err)
+			}
+			eolEntries = evaluateEOL(configStack, products, time.Now(), policy.WarnDays)
+			if date, ok := cache.SnapshotDate(); ok {
+				result.Catalog = describeCatalog(date, time.Now())
+			}
+		}
+		applyPolicy(&result, eolEntries, policy)
+
+		// Output results, writing the report to --output and a text summary to stdout
+		writeReport := func(w io.Writer) {
+			switch checkFormat {
+			case "json":
+				outputJSON(w, result)
+			case "sarif":
+				outputSARIF(w, result, sarifRoot(configDir), filepath.Base(absConfigPath))
+			case "junit":
+				outputJUnit(w, result)
+			default:
+				outputText(w, result)
+			}
+		}
+		if checkOutput != "" {
+			file, err := os.Create(checkOutput)
+			if err != nil {
+				helpers.ExitWithError(2, "failed to create report: %v", err)
+			}
+			writeReport(file)
+			if err := file.Close(); err != nil {
+				helpers.ExitWithError(2, "failed to write report: %v", err)
+			}
+			outputText(os.Stdout, result)
 		} else {
-			outputText(result)
+			writeReport(os.Stdout)
 		}
 
 		// Exit with appropriate code
@@ -98,42 +230,40 @@ var checkCmd = &cobra.Command{
 func normalizeDetectedToStack(info DetectedInfo) map[string]helpers.StackEntry {
 	normalized := make(map[string]helpers.StackEntry)
 
-	if len(info.Ruby) > 0 {
-		normalized["ruby"] = helpers.StackEntry{
-			Version: info.Ruby[0].Value,
-			Source:  info.Ruby[0].Source,
+	// Use the first candidate of each product, as in non-interactive selection
+	for product, candidates := range info.Products {
+		if len(candidates) == 0 {
+			continue
 		}
-	}
-
-	if len(info.Rails) > 0 {
-		normalized["rails"] = helpers.StackEntry{
-			Version: info.Rails[0].Value,
-			Source:  info.Rails[0].Source,
+		normalized[product] = helpers.StackEntry{
+			Version: candidates[0].Value,
+			Source:  candidates[0].Source,
+			Line:    candidates[0].Line,
 		}
 	}
 
-	if len(info.Node) > 0 {
-		normalized["nodejs"] = helpers.StackEntry{
-			Version: info.Node[0].Value,
-			Source:  info.Node[0].Source,
-		}
+	return normalized
+}
+
+// flattenProjectStacks merges a root stack and its sub-project stacks into a single stack,
+// prefixing sub-project technologies with their path (e.g. "apps/web/nodejs")
+func flattenProjectStacks(stack map[string]helpers.StackEntry, projects map[string]helpers.ProjectConfig) map[string]helpers.StackEntry {
+	if len(projects) == 0 {
+		return stack
 	}
 
-	if len(info.Go) > 0 {
-		normalized["go"] = helpers.StackEntry{
-			Version: info.Go[0].Value,
-			Source:  info.Go[0].Source,
-		}
+	flattened := make(map[string]helpers.StackEntry, len(stack))
+	for tech, entry := range stack {
+		flattened[tech] = entry
 	}
 
-	if len(info.Python) > 0 {
-		normalized["python"] = helpers.StackEntry{
-			Version: info.Python[0].Value,
-			Source:  info.Python[0].Source,
+	for path, project := range projects {
+		for tech, entry := range project.Stack {
+			flattened[path+"/"+tech] = entry
 		}
 	}
 
-	return normalized
+	return flattened
 }
 
 func compareStacks(configStack, detectedStack map[string]helpers.StackEntry) CheckResult {
@@ -142,18 +272,23 @@ func compareStacks(configStack, detectedStack map[string]helpers.StackEntry) Che
 			Matched:       []ComparisonEntry{},
 			Mismatched:    []ComparisonEntry{},
 			MissingConfig: []ComparisonEntry{},
+			Untracked:     []ComparisonEntry{},
+			EOL:           []EOLEntry{},
 		},
 	}
 
 	// Check all items in config
 	for tech, configEntry := range configStack {
 		if detectedEntry, exists := detectedStack[tech]; exists {
-			if configEntry.Version == detectedEntry.Version {
+			if versionMatches(configEntry.Version, detectedEntry.Version) {
 				result.Results.Matched = append(result.Results.Matched, ComparisonEntry{
 					Name:     tech,
 					Version:  configEntry.Version,
 					Detected: detectedEntry.Version,
 					Source:   detectedEntry.Source,
+					Line:     detectedEntry.Line,
+					RuleID:   ruleVersionMatch,
+					Severity: severityNone,
 				})
 				result.Summary.Matches++
 			} else {
@@ -162,19 +297,51 @@ func compareStacks(configStack, detectedStack map[string]helpers.StackEntry) Che
 					Version:  configEntry.Version,
 					Detected: detectedEntry.Version,
 					Source:   detectedEntry.Source,
+					Line:     detectedEntry.Line,
+					RuleID:   ruleVersionMismatch,
+					Severity: severityError,
 				})
 				result.Summary.Mismatches++
 			}
 		} else {
 			result.Results.MissingConfig = append(result.Results.MissingConfig, ComparisonEntry{
-				Name:    tech,
-				Version: configEntry.Version,
-				Source:  configEntry.Source,
+				Name:     tech,
+				Version:  configEntry.Version,
+				Source:   configEntry.Source,
+				Line:     configEntry.Line,
+				RuleID:   ruleMissing,
+				Severity: severityError,
 			})
 			result.Summary.MissingConfig++
 		}
 	}
 
+	// Report detected items that are not in config; they only fail the check with --fail-on untracked
+	for tech, detectedEntry := range detectedStack {
+		if _, exists := configStack[tech]; exists {
+			continue
+		}
+		result.Results.Untracked = append(result.Results.Untracked, ComparisonEntry{
+			Name:     tech,
+			Detected: detectedEntry.Version,
+			Source:   detectedEntry.Source,
+			Line:     detectedEntry.Line,
+			RuleID:   ruleUntracked,
+			Severity: severityWarning,
+		})
+		result.Summary.Untracked++
+	}
+
+	// Sort every bucket by name so that text, JSON and SARIF output is stable between runs
+	for _, entries := range [][]ComparisonEntry{
+		result.Results.Matched,
+		result.Results.Mismatched,
+		result.Results.MissingConfig,
+		result.Results.Untracked,
+	} {
+		sort.Slice(entries, func(i, j int) bool { return entries[i].Name < entries[j].Name })
+	}
+
 	// Determine overall status
 	if result.Summary.Mismatches == 0 && result.Summary.MissingConfig == 0 {
 		result.Status = "match"
@@ -185,58 +352,286 @@ func compareStacks(configStack, detectedStack map[string]helpers.StackEntry) Che
 	return result
 }
 
-func outputText(result CheckResult) {
-	fmt.Println("Technology Check Results")
-	fmt.Println("========================")
-	fmt.Println()
+// versionMatches reports whether a detected version satisfies the version or constraint
+// in stacktodate.yml, e.g. 3.2.2 satisfies "3.2", "~> 3.2" and ">=3 <4". Values that are
+// not versions, such as "lts/iron", must be equal.
+func versionMatches(constraint, detected string) bool {
+	if constraint == detected {
+		return true
+	}
+
+	c, err := versions.ParseConstraint(constraint)
+	if err != nil {
+		return false
+	}
+	v, err := versions.Parse(detected)
+	if err != nil {
+		return false
+	}
+	return c.Check(v)
+}
+
+// resolvePolicy merges the --fail-on and --warn-within flags over the config's policy section
+func resolvePolicy(config *helpers.PolicyConfig, failOn []string, warnWithin string) (checkPolicy, error) {
+	var policy checkPolicy
+
+	if config != nil {
+		if len(failOn) == 0 {
+			failOn = config.FailOn
+		}
+		if warnWithin == "" {
+			warnWithin = config.WarnWithin
+		}
+	}
+
+	for _, category := range failOn {
+		switch category {
+		case "eol":
+			policy.FailOnEOL = true
+		case "eol-soon":
+			policy.FailOnEOLSoon = true
+		case "untracked":
+			policy.FailOnUntracked = true
+		default:
+			return policy, fmt.Errorf("unknown fail-on category %q (expected eol, eol-soon or untracked)", category)
+		}
+	}
+
+	days, err := helpers.ParseDays(warnWithin)
+	if err != nil {
+		return policy, err
+	}
+	policy.WarnDays = days
+
+	// Without a window no component is ever reported as reaching EOL soon
+	if policy.FailOnEOLSoon && policy.WarnDays == 0 {
+		return policy, fmt.Errorf("fail-on eol-soon requires --warn-within or policy.warn_within")
+	}
+
+	return policy, nil
+}
+
+// evaluateEOL returns the tracked components that are past their end of life or,
+// when warnDays is set, reach it within that many days. Sub-project entries such
+// as "apps/web/nodejs" are looked up by their last path element.
+func evaluateEOL(stack map[string]helpers.StackEntry, products []cache.Product, now time.Time, warnDays int) []EOLEntry {
+	names := make([]string, 0, len(stack))
+	for name := range stack {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	entries := []EOLEntry{}
+	for _, name := range names {
+		entry := stack[name]
+
+		product := cache.GetProductByKey(path.Base(name), products)
+		if product == nil {
+			continue
+		}
+		release := product.FindRelease(lowestVersion(entry.Version))
+		if release == nil {
+			continue
+		}
+
+		eolEntry := EOLEntry{Name: name, Version: entry.Version, EOLDate: release.EOL, Line: entry.Line, Overlay: release.Overlay}
+		date, hasDate := release.EOLDate()
+		if hasDate {
+			days := int(date.Sub(today).Hours() / 24)
+			eolEntry.DaysRemaining = &days
+		} else {
+			// The catalog may only flag a release as EOL without a date
+			eolEntry.EOLDate = ""
+		}
+
+		switch {
+		case release.IsEOL(today):
+			eolEntry.Status, eolEntry.RuleID = "eol", ruleEOL
+		case hasDate && warnDays > 0 && *eolEntry.DaysRemaining <= warnDays:
+			eolEntry.Status, eolEntry.RuleID = "eol_soon", ruleEOLSoon
+		default:
+			continue
+		}
+
+		entries = append(entries, eolEntry)
+	}
+
+	return entries
+}
+
+// lowestVersion returns the lowest version allowed by a version constraint, e.g. 3.2 for
+// "~> 3.2", since it is the first to reach its end of life
+func lowestVersion(constraint string) string {
+	if c, err := versions.ParseConstraint(constraint); err == nil {
+		if v, ok := c.Min(); ok {
+			return v.String()
+		}
+	}
+	return constraint
+}
+
+// applyPolicy adds the EOL entries to the result and fails it when the policy is violated.
+// Entries the policy fails on are errors, the others warnings.
+func applyPolicy(result *CheckResult, entries []EOLEntry, policy checkPolicy) {
+	if policy.FailOnUntracked {
+		for i := range result.Results.Untracked {
+			result.Results.Untracked[i].Severity = severityError
+		}
+	}
+
+	if entries != nil {
+		result.Results.EOL = entries
+	}
+	for i, entry := range entries {
+		failing := policy.FailOnEOLSoon
+		if entry.Status == "eol" {
+			result.Summary.EOL++
+			failing = failing || policy.FailOnEOL
+		} else {
+			result.Summary.EOLSoon++
+		}
+
+		result.Results.EOL[i].Severity = severityWarning
+		if failing {
+			result.Results.EOL[i].Severity = severityError
+		}
+	}
+
+	violated := (policy.FailOnEOL && result.Summary.EOL > 0) ||
+		(policy.FailOnEOLSoon && result.Summary.EOL+result.Summary.EOLSoon > 0) ||
+		(policy.FailOnUntracked && result.Summary.Untracked > 0)
+	if violated && result.Status == "match" {
+		result.Status = "policy_violation"
+	}
+}
+
+// describeCatalog returns the snapshot date and age of the catalog in use
+func describeCatalog(snapshotDate, now time.Time) *CatalogInfo {
+	days := int(now.Sub(snapshotDate).Hours() / 24)
+	return &CatalogInfo{
+		SnapshotDate: snapshotDate.Format("2006-01-02"),
+		AgeDays:      days,
+		Stale:        days > staleCatalogDays,
+	}
+}
+
+func outputText(w io.Writer, result CheckResult) {
+	fmt.Fprintln(w, "Technology Check Results")
+	fmt.Fprintln(w, "========================")
+	fmt.Fprintln(w)
 
 	if len(result.Results.Matched) > 0 {
-		fmt.Printf("MATCH (%d):\n", len(result.Results.Matched))
+		fmt.Fprintf(w, "MATCH (%d):\n", len(result.Results.Matched))
 		for _, entry := range result.Results.Matched {
-			fmt.Printf("  %-12s %s == %s   ✓\n", entry.Name+":", entry.Version, entry.Detected)
+			if entry.Version == entry.Detected {
+				fmt.Fprintf(w, "  %-12s %s == %s   ✓\n", entry.Name+":", entry.Version, entry.Detected)
+			} else {
+				fmt.Fprintf(w, "  %-12s %s satisfies %s   ✓\n", entry.Name+":", entry.Detected, entry.Version)
+			}
 		}
-		fmt.Println()
+		fmt.Fprintln(w)
 	}
 
 	if len(result.Results.Mismatched) > 0 {
-		fmt.Printf("MISMATCH (%d):\n", len(result.Results.Mismatched))
+		fmt.Fprintf(w, "MISMATCH (%d):\n", len(result.Results.Mismatched))
 		for _, entry := range result.Results.Mismatched {
-			fmt.Printf("  %-12s %s != %s   (config has %s)\n", entry.Name+":", entry.Detected, entry.Version, entry.Version)
+			fmt.Fprintf(w, "  %-12s %s != %s   (config has %s)\n", entry.Name+":", entry.Detected, entry.Version, entry.Version)
 		}
-		fmt.Println()
+		fmt.Fprintln(w)
 	}
 
 	if len(result.Results.MissingConfig) > 0 {
-		fmt.Printf("MISSING FROM DETECTION (%d):\n", len(result.Results.MissingConfig))
+		fmt.Fprintf(w, "IN CONFIG, NOT DETECTED (%d):\n", len(result.Results.MissingConfig))
 		for _, entry := range result.Results.MissingConfig {
-			fmt.Printf("  %-12s %s   (in config but not detected)\n", entry.Name+":", entry.Version)
+			fmt.Fprintf(w, "  %-12s %s   (in config but not detected)\n", entry.Name+":", entry.Version)
 		}
-		fmt.Println()
+		fmt.Fprintln(w)
 	}
 
-	fmt.Printf("Summary: %d match, %d mismatch, %d missing\n",
+	if len(result.Results.Untracked) > 0 {
+		fmt.Fprintf(w, "DETECTED, NOT IN CONFIG (%d):\n", len(result.Results.Untracked))
+		for _, entry := range result.Results.Untracked {
+			fmt.Fprintf(w, "  %-12s %s   (detected in %s but not in config)\n", entry.Name+":", entry.Detected, entry.Source)
+		}
+		fmt.Fprintln(w)
+	}
+
+	if len(result.Results.EOL) > 0 {
+		fmt.Fprintf(w, "END OF LIFE (%d):\n", len(result.Results.EOL))
+		for _, entry := range result.Results.EOL {
+			fmt.Fprintf(w, "  %-12s %s   %s\n", entry.Name+":", entry.Version, describeEOL(entry))
+		}
+		fmt.Fprintln(w)
+	}
+
+	if result.Catalog != nil && result.Catalog.Stale {
+		fmt.Fprintf(w, "⚠ EOL data comes from a catalog snapshot of %s (%d days old)\n\n", result.Catalog.SnapshotDate, result.Catalog.AgeDays)
+	}
+
+	fmt.Fprintf(w, "Summary: %d match, %d mismatch, %d missing, %d untracked",
 		result.Summary.Matches,
 		result.Summary.Mismatches,
-		result.Summary.MissingConfig)
+		result.Summary.MissingConfig,
+		result.Summary.Untracked)
+	if len(result.Results.EOL) > 0 {
+		fmt.Fprintf(w, ", %d eol, %d eol soon", result.Summary.EOL, result.Summary.EOLSoon)
+	}
+	fmt.Fprintln(w)
+
+	switch result.Status {
+	case "mismatch":
+		fmt.Fprintln(w, "Exit code: 1 (has differences)")
+	case "policy_violation":
+		fmt.Fprintln(w, "Exit code: 1 (policy violated)")
+	default:
+		fmt.Fprintln(w, "Exit code: 0 (all match)")
+	}
+}
 
-	if result.Status == "mismatch" {
-		fmt.Println("Exit code: 1 (has differences)")
-	} else {
-		fmt.Println("Exit code: 0 (all match)")
+// describeEOL formats the EOL date and days remaining of an entry, noting when the
+// date comes from the local catalog overlay
+func describeEOL(entry EOLEntry) string {
+	description := describeEOLDate(entry)
+	if entry.Overlay {
+		description += " [catalog overlay]"
+	}
+	return description
+}
+
+func describeEOLDate(entry EOLEntry) string {
+	if entry.DaysRemaining == nil {
+		return "(past EOL)"
+	}
+
+	days := *entry.DaysRemaining
+	switch {
+	case days < 0:
+		return fmt.Sprintf("(EOL since %s, %d days ago)", entry.EOLDate, -days)
+	case days == 0:
+		return fmt.Sprintf("(EOL today, %s)", entry.EOLDate)
+	case entry.Status == "eol_soon":
+		return fmt.Sprintf("(EOL on %s, in %d days)", entry.EOLDate, days)
 	}
+	return fmt.Sprintf("(EOL on %s)", entry.EOLDate)
 }
 
-func outputJSON(result CheckResult) {
+func outputJSON(w io.Writer, result CheckResult) {
 	data, err := json.MarshalIndent(result, "", "  ")
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error marshaling JSON: %v\n", err)
 		os.Exit(2)
 	}
-	fmt.Println(string(data))
+	fmt.Fprintln(w, string(data))
 }
 
 func init() {
 	rootCmd.AddCommand(checkCmd)
 	checkCmd.Flags().StringVarP(&checkConfigFile, "config", "c", "", "Path to stacktodate.yml config file (default: stacktodate.yml)")
-	checkCmd.Flags().StringVarP(&checkFormat, "format", "f", "text", "Output format: text or json (default: text)")
+	checkCmd.Flags().StringVarP(&checkFormat, "format", "f", "text", "Output format: text, json, sarif or junit (default: text)")
+	checkCmd.Flags().StringVarP(&checkOutput, "output", "o", "", "Write the report to a file and print a text summary to stdout")
+	checkCmd.Flags().StringVar(&checkRef, "ref", "", "Detect versions at a git revision instead of the working tree")
+	checkCmd.Flags().StringSliceVar(&checkFailOn, "fail-on", nil, "Also fail on: eol (past end of life), eol-soon (within --warn-within), untracked (not in config)")
+	checkCmd.Flags().StringVar(&checkWarnWithin, "warn-within", "", "Warn about components reaching EOL within this window, e.g. 90d, 12w, 6m")
 }
@@ -2,26 +2,31 @@ package cmd
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stacktodate/stacktodate-cli/cmd/helpers"
+	"github.com/stacktodate/stacktodate-cli/cmd/lib/cache"
 )
 
 func TestNormalizeDetectedToStack(t *testing.T) {
 	info := DetectedInfo{
-		Ruby: []Candidate{
-			{Value: "3.2.0", Source: ".ruby-version"},
-		},
-		Rails: []Candidate{
-			{Value: "7.0.0", Source: "Gemfile"},
-		},
-		Node: []Candidate{
-			{Value: "18.0.0", Source: ".nvmrc"},
-		},
-		Go: []Candidate{
-			{Value: "1.21", Source: "go.mod"},
-		},
-		Python: []Candidate{
-			{Value: "3.11", Source: ".python-version"},
+		Products: map[string][]Candidate{
+			"ruby": {
+				{Value: "3.2.0", Source: ".ruby-version"},
+			},
+			"rails": {
+				{Value: "7.0.0", Source: "Gemfile"},
+			},
+			"nodejs": {
+				{Value: "18.0.0", Source: ".nvmrc"},
+				{Value: "18", Source: "Dockerfile"},
+			},
+			"go": {
+				{Value: "1.21", Source: "go.mod"},
+			},
+			"python": {
+				{Value: "3.11", Source: ".python-version"},
+			},
 		},
 	}
 
@@ -165,6 +170,95 @@ func TestCompareStacks_Complex(t *testing.T) {
 	if result.Summary.MissingConfig != 1 {
 		t.Errorf("compareStacks: expected 1 missing config, got %d", result.Summary.MissingConfig)
 	}
+
+	if result.Summary.Untracked != 1 {
+		t.Errorf("compareStacks: expected 1 untracked, got %d", result.Summary.Untracked)
+	}
+}
+
+func TestCompareStacks_Untracked(t *testing.T) {
+	configStack := map[string]helpers.StackEntry{
+		"ruby": {Version: "3.2.0", Source: ".ruby-version"},
+	}
+
+	detectedStack := map[string]helpers.StackEntry{
+		"ruby":            {Version: "3.2.0", Source: ".ruby-version"},
+		"apps/web/nodejs": {Version: "20", Source: ".nvmrc", Line: 1},
+	}
+
+	result := compareStacks(configStack, detectedStack)
+
+	// Untracked technologies are reported without failing the check
+	if result.Status != "match" {
+		t.Errorf("compareStacks: expected status 'match', got %s", result.Status)
+	}
+
+	if len(result.Results.Untracked) != 1 {
+		t.Fatalf("compareStacks: expected 1 item in Untracked, got %d", len(result.Results.Untracked))
+	}
+
+	entry := result.Results.Untracked[0]
+	if entry.Name != "apps/web/nodejs" || entry.Detected != "20" || entry.RuleID != ruleUntracked || entry.Severity != severityWarning {
+		t.Errorf("compareStacks: unexpected untracked entry %+v", entry)
+	}
+
+	applyPolicy(&result, nil, checkPolicy{FailOnUntracked: true})
+
+	if result.Status != "policy_violation" {
+		t.Errorf("applyPolicy: expected status 'policy_violation', got %s", result.Status)
+	}
+	if result.Results.Untracked[0].Severity != severityError {
+		t.Errorf("applyPolicy: expected untracked severity error, got %s", result.Results.Untracked[0].Severity)
+	}
+}
+
+func TestCompareStacks_Constraints(t *testing.T) {
+	configStack := map[string]helpers.StackEntry{
+		"ruby":   {Version: "3.2", Source: ".ruby-version"},
+		"nodejs": {Version: ">=18 <21", Source: ".nvmrc"},
+		"rails":  {Version: "~> 7.1", Source: "Gemfile.lock"},
+		"python": {Version: "3.x", Source: ".python-version"},
+		"go":     {Version: "1.22", Source: "go.mod"},
+	}
+
+	detectedStack := map[string]helpers.StackEntry{
+		"ruby":   {Version: "3.2.2", Source: ".ruby-version"},
+		"nodejs": {Version: "18.19.0", Source: ".nvmrc"},
+		"rails":  {Version: "7.2.1", Source: "Gemfile.lock"},
+		"python": {Version: "3.12", Source: ".python-version"},
+		"go":     {Version: "1.21", Source: "go.mod"},
+	}
+
+	result := compareStacks(configStack, detectedStack)
+
+	if result.Summary.Matches != 4 {
+		t.Errorf("compareStacks: expected 4 matches, got %d: %+v", result.Summary.Matches, result.Results.Mismatched)
+	}
+
+	if len(result.Results.Mismatched) != 1 || result.Results.Mismatched[0].Name != "go" {
+		t.Errorf("compareStacks: expected go to mismatch, got %+v", result.Results.Mismatched)
+	}
+}
+
+func TestVersionMatches(t *testing.T) {
+	tests := []struct {
+		constraint string
+		detected   string
+		expected   bool
+	}{
+		{"18", "18.19.0", true},
+		{"~> 3.2", "3.3.0", true},
+		{"~> 3.2", "4.0.0", false},
+		{"lts/iron", "lts/iron", true},
+		{"lts/iron", "20.11.0", false},
+		{"3.2", "latest", false},
+	}
+
+	for _, tt := range tests {
+		if result := versionMatches(tt.constraint, tt.detected); result != tt.expected {
+			t.Errorf("versionMatches(%q, %q) = %v, want %v", tt.constraint, tt.detected, result, tt.expected)
+		}
+	}
 }
 
 func TestCompareStacks_Empty(t *testing.T) {
@@ -181,3 +275,217 @@ func TestCompareStacks_Empty(t *testing.T) {
 		t.Errorf("compareStacks: expected 0 matches, got %d", result.Summary.Matches)
 	}
 }
+
+func TestFlattenProjectStacks(t *testing.T) {
+	stack := map[string]helpers.StackEntry{
+		"go": {Version: "1.22", Source: "go.mod"},
+	}
+	projects := map[string]helpers.ProjectConfig{
+		"apps/web": {Stack: map[string]helpers.StackEntry{
+			"nodejs": {Version: "20", Source: ".nvmrc"},
+		}},
+		"services/billing": {Stack: map[string]helpers.StackEntry{
+			"ruby":  {Version: "3.2", Source: ".ruby-version"},
+			"rails": {Version: "7.1", Source: "Gemfile.lock"},
+		}},
+	}
+
+	result := flattenProjectStacks(stack, projects)
+
+	expected := map[string]string{
+		"go":                     "1.22",
+		"apps/web/nodejs":        "20",
+		"services/billing/ruby":  "3.2",
+		"services/billing/rails": "7.1",
+	}
+
+	if len(result) != len(expected) {
+		t.Errorf("flattenProjectStacks: expected %d entries, got %d: %v", len(expected), len(result), result)
+	}
+	for tech, version := range expected {
+		if result[tech].Version != version {
+			t.Errorf("flattenProjectStacks: expected %s at %s, got %q", tech, version, result[tech].Version)
+		}
+	}
+
+	if len(stack) != 1 {
+		t.Errorf("flattenProjectStacks: expected root stack to be left untouched, got %v", stack)
+	}
+}
+
+func TestResolvePolicy(t *testing.T) {
+	config := &helpers.PolicyConfig{FailOn: []string{"eol"}, WarnWithin: "12w"}
+
+	policy, err := resolvePolicy(config, nil, "")
+	if err != nil {
+		t.Fatalf("resolvePolicy: unexpected error: %v", err)
+	}
+	if !policy.FailOnEOL || policy.FailOnEOLSoon || policy.WarnDays != 84 {
+		t.Errorf("resolvePolicy: expected config policy, got %+v", policy)
+	}
+
+	// Flags take precedence over the config
+	policy, err = resolvePolicy(config, []string{"eol-soon"}, "90d")
+	if err != nil {
+		t.Fatalf("resolvePolicy: unexpected error: %v", err)
+	}
+	if policy.FailOnEOL || !policy.FailOnEOLSoon || policy.WarnDays != 90 {
+		t.Errorf("resolvePolicy: expected flag policy, got %+v", policy)
+	}
+
+	if policy, _ := resolvePolicy(nil, nil, ""); policy.NeedsEOLData() {
+		t.Errorf("resolvePolicy: expected no policy without config or flags, got %+v", policy)
+	}
+
+	if policy, _ := resolvePolicy(nil, []string{"untracked"}, ""); !policy.FailOnUntracked || policy.NeedsEOLData() {
+		t.Errorf("resolvePolicy: expected untracked policy without EOL data, got %+v", policy)
+	}
+
+	if _, err := resolvePolicy(nil, []string{"outdated"}, ""); err == nil {
+		t.Errorf("resolvePolicy: expected error for unknown category")
+	}
+	if _, err := resolvePolicy(nil, nil, "soon"); err == nil {
+		t.Errorf("resolvePolicy: expected error for invalid window")
+	}
+
+	// eol-soon can never fail without a window
+	if _, err := resolvePolicy(nil, []string{"eol-soon"}, ""); err == nil {
+		t.Errorf("resolvePolicy: expected error for eol-soon without a window")
+	}
+	if _, err := resolvePolicy(&helpers.PolicyConfig{FailOn: []string{"eol-soon"}}, nil, ""); err == nil {
+		t.Errorf("resolvePolicy: expected error for eol-soon in the config without a window")
+	}
+	if _, err := resolvePolicy(&helpers.PolicyConfig{FailOn: []string{"eol-soon"}}, nil, "30d"); err != nil {
+		t.Errorf("resolvePolicy: unexpected error for eol-soon with a --warn-within window: %v", err)
+	}
+}
+
+func TestEvaluateEOL(t *testing.T) {
+	products := []cache.Product{
+		{Key: "ruby", Releases: []cache.Release{
+			{ReleaseCycle: "3.3", EOL: "2027-03-31"},
+			{ReleaseCycle: "2.7", EOL: "2023-03-31"},
+		}},
+		{Key: "nodejs", Releases: []cache.Release{
+			{ReleaseCycle: "20", EOL: "2026-04-30"},
+			{ReleaseCycle: "12", EOL: "true"},
+		}},
+		{Key: "go", Releases: []cache.Release{
+			{ReleaseCycle: "1.22"},
+		}},
+	}
+	stack := map[string]helpers.StackEntry{
+		"ruby":            {Version: "2.7"},
+		"apps/web/ruby":   {Version: "3.3.1"},
+		"nodejs":          {Version: "20"},
+		"apps/old/nodejs": {Version: "12"},
+		"go":              {Version: "1.22"},
+		"erlang":          {Version: "26"},
+		"apps/api/ruby":   {Version: "~> 2.7"},
+	}
+	now := time.Date(2026, 3, 1, 15, 0, 0, 0, time.UTC)
+
+	entries := evaluateEOL(stack, products, now, 90)
+
+	expected := []struct {
+		name   string
+		status string
+		days   *int
+	}{
+		{"apps/api/ruby", "eol", intPtr(-1066)},
+		{"apps/old/nodejs", "eol", nil},
+		{"nodejs", "eol_soon", intPtr(60)},
+		{"ruby", "eol", intPtr(-1066)},
+	}
+	if len(entries) != len(expected) {
+		t.Fatalf("evaluateEOL: expected %d entries, got %d: %+v", len(expected), len(entries), entries)
+	}
+	for i, e := range expected {
+		entry := entries[i]
+		if entry.Name != e.name || entry.Status != e.status {
+			t.Errorf("evaluateEOL: expected %s (%s) at index %d, got %s (%s)", e.name, e.status, i, entry.Name, entry.Status)
+		}
+		if (entry.DaysRemaining == nil) != (e.days == nil) || (e.days != nil && *entry.DaysRemaining != *e.days) {
+			t.Errorf("evaluateEOL: unexpected days remaining for %s: %v", e.name, entry.DaysRemaining)
+		}
+	}
+
+	// Without a warning window only past-EOL components are reported
+	if entries := evaluateEOL(stack, products, now, 0); len(entries) != 3 {
+		t.Errorf("evaluateEOL: expected 3 entries without window, got %d: %+v", len(entries), entries)
+	}
+}
+
+func TestApplyPolicy(t *testing.T) {
+	entries := []EOLEntry{
+		{Name: "nodejs", Version: "20", Status: "eol_soon"},
+	}
+
+	tests := []struct {
+		name     string
+		policy   checkPolicy
+		status   string
+		expected string
+	}{
+		{"warning only", checkPolicy{WarnDays: 90}, "match", "match"},
+		{"fail on eol ignores eol soon", checkPolicy{FailOnEOL: true, WarnDays: 90}, "match", "match"},
+		{"fail on eol soon", checkPolicy{FailOnEOLSoon: true, WarnDays: 90}, "match", "policy_violation"},
+		{"mismatch takes precedence", checkPolicy{FailOnEOLSoon: true, WarnDays: 90}, "mismatch", "mismatch"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := CheckResult{Status: tt.status}
+			applyPolicy(&result, entries, tt.policy)
+
+			if result.Status != tt.expected {
+				t.Errorf("applyPolicy: expected status %s, got %s", tt.expected, result.Status)
+			}
+			if result.Summary.EOLSoon != 1 || result.Summary.EOL != 0 {
+				t.Errorf("applyPolicy: unexpected summary %+v", result.Summary)
+			}
+		})
+	}
+}
+
+func TestDescribeCatalog(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		snapshot time.Time
+		age      int
+		stale    bool
+	}{
+		{time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC), 15, false},
+		{time.Date(2026, 9, 16, 12, 0, 0, 0, time.UTC), 30, false},
+		{time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC), 137, true},
+	}
+
+	for _, tt := range tests {
+		info := describeCatalog(tt.snapshot, now)
+		if info.AgeDays != tt.age || info.Stale != tt.stale || info.SnapshotDate != tt.snapshot.Format("2006-01-02") {
+			t.Errorf("describeCatalog(%s) = %+v, want %d days, stale %v", tt.snapshot.Format("2006-01-02"), *info, tt.age, tt.stale)
+		}
+	}
+}
+
+func TestDescribeEOL(t *testing.T) {
+	tests := []struct {
+		entry    EOLEntry
+		expected string
+	}{
+		{EOLEntry{Status: "eol", EOLDate: "2025-04-30", DaysRemaining: intPtr(-10)}, "(EOL since 2025-04-30, 10 days ago)"},
+		{EOLEntry{Status: "eol_soon", EOLDate: "2027-04-30", DaysRemaining: intPtr(196), Overlay: true}, "(EOL on 2027-04-30, in 196 days) [catalog overlay]"},
+		{EOLEntry{Status: "eol"}, "(past EOL)"},
+	}
+
+	for _, tt := range tests {
+		if description := describeEOL(tt.entry); description != tt.expected {
+			t.Errorf("describeEOL(%+v) = %q, want %q", tt.entry, description, tt.expected)
+		}
+	}
+}
+
+func intPtr(v int) *int {
+	return &v
+}
@@ -2,64 +2,106 @@ package cmd
 
 import (
 	"fmt"
-	"regexp"
+	"io/fs"
+	"path/filepath"
+	"sort"
 	"strings"
 
+	"github.com/stacktodate/stacktodate-cli/cmd/helpers"
 	"github.com/stacktodate/stacktodate-cli/cmd/lib/cache"
 	"github.com/stacktodate/stacktodate-cli/cmd/lib/detectors"
+	"github.com/stacktodate/stacktodate-cli/cmd/lib/gitfs"
+	"github.com/stacktodate/stacktodate-cli/cmd/lib/versions"
 )
 
 // Candidate alias for easier access
 type Candidate = detectors.Candidate
 
+// DetectedInfo holds detection results keyed by catalog product key
 type DetectedInfo struct {
-	Ruby   []detectors.Candidate
-	Rails  []detectors.Candidate
-	Node   []detectors.Candidate
-	Go     []detectors.Candidate
-	Python []detectors.Candidate
-	Docker []detectors.Candidate
+	Products map[string][]detectors.Candidate
+	Docker   []detectors.Candidate // Docker images not matching any registered detector
+}
+
+// SubProject holds the detection results for one project root found by a recursive scan
+type SubProject struct {
+	Path string // Relative to the scanned directory, "." for the directory itself
+	Info DetectedInfo
 }
 
 // cleanVersion removes version operators and extracts the core version
 // Examples: ~> 7.1.0 -> 7.1.0, >= 18.0.0 -> 18.0.0, <= 3.11 -> 3.11
 func cleanVersion(version string) string {
-	// Trim whitespace
 	version = strings.TrimSpace(version)
 
-	// Remove common version operators
-	operators := []string{"~>", ">=", "<=", "!=", "==", "^", "~", ">", "<", "="}
-	for _, op := range operators {
-		if strings.HasPrefix(version, op) {
-			version = strings.TrimPrefix(version, op)
-			version = strings.TrimSpace(version)
-		}
-	}
-
-	// Extract only the version number part (in case there are comments or extra text)
-	re := regexp.MustCompile(`^([\d.]+)`)
-	matches := re.FindStringSubmatch(version)
-	if len(matches) > 1 {
-		return matches[1]
+	// Keep values that are not versions, such as "system" or "lts/iron", as they are
+	if v, err := versions.Parse(strings.TrimLeft(version, "~^<>=! ")); err == nil {
+		return v.String()
 	}
 
 	return version
 }
 
-// cleanCandidateVersions applies cleanVersion to all candidates in a slice
-func cleanCandidateVersions(candidates []detectors.Candidate) []detectors.Candidate {
+// resolveCandidateVersions parses each candidate's value in its syntax and records the
+// lowest and highest of the product's release cycles it allows. The value becomes the
+// lowest cycle, or the highest one for specs without lower bound such as "<3.12".
+// The spec keeps the value as written unless a detector already set it.
+func resolveCandidateVersions(candidates []detectors.Candidate, cycles []string) []detectors.Candidate {
 	for i := range candidates {
-		candidates[i].Value = cleanVersion(candidates[i].Value)
+		candidate := &candidates[i]
+		value := strings.TrimSpace(candidate.Value)
+		if candidate.Spec == "" {
+			candidate.Spec = value
+		}
+
+		constraint, err := versions.ParseSpec(candidate.Syntax, value)
+		if err != nil {
+			// Not a version, e.g. "system" or "latest"
+			candidate.Value = cleanVersion(value)
+			continue
+		}
+
+		lowest, hasLowest := constraint.Min()
+		if min, max, ok := constraint.Cycles(cycles); ok {
+			candidate.MinCycle, candidate.MaxCycle = min, max
+			candidate.Value = min
+			if !hasLowest {
+				candidate.Value = max
+			}
+			continue
+		}
+
+		// Without catalog data, fall back to the lowest version the spec allows
+		candidate.Value = cleanVersion(value)
+		if hasLowest {
+			candidate.Value = lowest.String()
+		}
 	}
+
 	return candidates
 }
 
-// truncateCandidateVersions truncates all candidate versions to match stacktodate.club API cycles
-func truncateCandidateVersions(candidates []detectors.Candidate, product string) []detectors.Candidate {
-	for i := range candidates {
-		candidates[i].Value = truncateVersionToEOLCycle(product, candidates[i].Value)
+// productReleases returns the releases of a product in the products cache
+func productReleases(product string) []cache.Release {
+	products, err := cache.GetProducts()
+	if err != nil {
+		return nil
 	}
-	return candidates
+
+	cachedProduct := cache.GetProductByKey(product, products)
+	if cachedProduct == nil {
+		return nil
+	}
+	return cachedProduct.Releases
+}
+
+// releaseCycles returns the cycles of the given releases
+func releaseCycles(releases []cache.Release) []string {
+	cycles := make([]string, 0, len(releases))
+	for _, release := range releases {
+		cycles = append(cycles, release.ReleaseCycle)
+	}
+	return cycles
 }
 
 // extractVersionFromDockerImage extracts version from Docker image names
@@ -73,149 +115,153 @@ func extractVersionFromDockerImage(image string) string {
 
 	versionPart := parts[1]
 
-	// Remove common suffixes like -alpine, -slim, -bullseye, etc.
-	re := regexp.MustCompile(`^([\d.]+)`)
-	matches := re.FindStringSubmatch(versionPart)
-	if len(matches) > 1 {
-		return matches[1]
+	// Remove common suffixes like -alpine, -slim, -bullseye, etc. Tags such as v1.0 are kept as they are
+	if v, err := versions.Parse(versionPart); err == nil && !strings.HasPrefix(versionPart, "v") {
+		return v.String()
 	}
 
 	return versionPart
 }
 
-func DetectProjectInfo() DetectedInfo {
-	info := DetectedInfo{
-		Ruby:   detectors.DetectRubyVersion(),
-		Rails:  detectors.DetectRails(),
-		Node:   detectors.DetectNode(),
-		Go:     detectors.DetectGo(),
-		Python: detectors.DetectPython(),
-		Docker: detectors.DetectDocker(),
+// imageTag returns the tag of a Docker image, e.g. 3.11-slim for python:3.11-slim
+func imageTag(image string) string {
+	if i := strings.LastIndex(image, ":"); i >= 0 && !strings.Contains(image[i:], "/") {
+		return image[i+1:]
 	}
+	return ""
+}
 
-	// Clean versions for all detected candidates
-	info.Ruby = cleanCandidateVersions(info.Ruby)
-	info.Rails = cleanCandidateVersions(info.Rails)
-	info.Node = cleanCandidateVersions(info.Node)
-	info.Go = cleanCandidateVersions(info.Go)
-	info.Python = cleanCandidateVersions(info.Python)
-
-	// Aggregate Docker images into technology stacks
-	for _, dockerCandidate := range info.Docker {
-		image := dockerCandidate.Value
-		version := extractVersionFromDockerImage(image)
-
-		// Ruby
-		if strings.Contains(image, "ruby:") {
-			info.Ruby = append(info.Ruby, detectors.Candidate{
-				Value:  version,
-				Source: dockerCandidate.Source,
-			})
-		}
-		// Python
-		if strings.Contains(image, "python:") {
-			info.Python = append(info.Python, detectors.Candidate{
-				Value:  version,
-				Source: dockerCandidate.Source,
-			})
-		}
-		// Node.js
-		if strings.Contains(image, "node:") {
-			info.Node = append(info.Node, detectors.Candidate{
-				Value:  version,
-				Source: dockerCandidate.Source,
-			})
-		}
-		// Go
-		if strings.Contains(image, "golang:") || strings.Contains(image, "go:") {
-			info.Go = append(info.Go, detectors.Candidate{
-				Value:  version,
-				Source: dockerCandidate.Source,
-			})
-		}
+// openProjectFS returns the files below dir, read from disk or, when ref is
+// set, from that revision of the git repository containing dir
+func openProjectFS(dir, ref string) (fs.FS, error) {
+	if ref == "" {
+		return helpers.OpenDir(dir)
 	}
 
-	// Truncate versions to match stacktodate.club API cycles
-	info.Ruby = truncateCandidateVersions(info.Ruby, "ruby")
-	info.Rails = truncateCandidateVersions(info.Rails, "rails")
-	info.Node = truncateCandidateVersions(info.Node, "nodejs")
-	info.Go = truncateCandidateVersions(info.Go, "go")
-	info.Python = truncateCandidateVersions(info.Python, "python")
+	repo, err := gitfs.Open(dir)
+	if err != nil {
+		return nil, err
+	}
 
-	return info
-}
+	fsys, err := repo.FS(ref)
+	if err != nil {
+		return nil, err
+	}
 
-// mapProductNameToCacheKey maps internal product names to stacktodate.club API keys
-func mapProductNameToCacheKey(product string) string {
-	mapping := map[string]string{
-		"ruby":   "ruby",
-		"rails":  "rails",
-		"nodejs": "nodejs",
-		"go":     "go",
-		"python": "python",
+	absDir, err := helpers.ResolveAbsPath(dir)
+	if err != nil {
+		return nil, err
+	}
+	rel, err := filepath.Rel(repo.WorkTree(), absDir)
+	if err != nil {
+		return nil, fmt.Errorf("resolving %s in repository: %w", dir, err)
+	}
+	rel = filepath.ToSlash(rel)
+	if rel == "." {
+		return fsys, nil
 	}
 
-	if key, exists := mapping[product]; exists {
-		return key
+	if info, err := fs.Stat(fsys, rel); err != nil || !info.IsDir() {
+		return nil, fmt.Errorf("directory %s does not exist at %s", rel, ref)
 	}
-	return product
+	return fs.Sub(fsys, rel)
 }
 
-// truncateVersionToEOLCycle truncates a version to match the format used by stacktodate.club API
-// It tries to find the best matching cycle by progressively truncating the version
-// Examples: 3.11.0 -> 3.11, 18.0.0 -> 18, 7.1.0 -> 7.1
-func truncateVersionToEOLCycle(product, version string) string {
-	if product == "" || version == "" {
-		return version
+// DetectProjectInfo runs every registered detector and source against fsys
+func DetectProjectInfo(fsys fs.FS) DetectedInfo {
+	info := DetectedInfo{
+		Products: make(map[string][]detectors.Candidate),
 	}
 
-	// Get products from cache (auto-fetches if needed or stale)
-	products, err := cache.GetProducts()
-	if err != nil {
-		// Graceful fallback: return original version if cache fetch fails
-		return version
+	// Run every registered detector
+	for _, detector := range detectors.All() {
+		candidates := detector.Detect(fsys)
+		if len(candidates) > 0 {
+			info.Products[detector.Key()] = candidates
+		}
 	}
 
-	// Map product name to cache key
-	cacheKey := mapProductNameToCacheKey(product)
-	cachedProduct := cache.GetProductByKey(cacheKey, products)
-	if cachedProduct == nil {
-		// Product not found in cache, return original version
-		return version
+	// Merge candidates from multi-product sources such as .tool-versions
+	for _, source := range detectors.Sources() {
+		for product, candidates := range source.Detect(fsys) {
+			info.Products[product] = append(info.Products[product], candidates...)
+		}
 	}
 
-	// Build a set of available cycles for quick lookup
-	cycles := make(map[string]bool)
-	for _, release := range cachedProduct.Releases {
-		cycles[release.ReleaseCycle] = true
+	// Aggregate Docker images into technology stacks
+	for _, dockerCandidate := range detectors.DetectDocker(fsys) {
+		detector, ok := detectors.MatchImage(dockerCandidate.Value)
+		if !ok {
+			info.Docker = append(info.Docker, dockerCandidate)
+			continue
+		}
+
+		info.Products[detector.Key()] = append(info.Products[detector.Key()], detectors.Candidate{
+			Value:  extractVersionFromDockerImage(dockerCandidate.Value),
+			Source: dockerCandidate.Source,
+			Line:   dockerCandidate.Line,
+			Syntax: versions.DockerTag,
+			Spec:   imageTag(dockerCandidate.Value),
+		})
 	}
 
-	// If exact match exists, return as-is
-	if cycles[version] {
-		return version
+	// Resolve versions to stacktodate.club API cycles
+	for product, candidates := range info.Products {
+		releases := productReleases(product)
+		if product == "nodejs" {
+			candidates = resolveNodeAliases(candidates, releases)
+		}
+		info.Products[product] = resolveCandidateVersions(candidates, releaseCycles(releases))
 	}
 
-	// Split version into parts
-	parts := strings.Split(version, ".")
+	return info
+}
+
+// DetectConfigProjects detects the root of fsys and every sub-project listed in a
+// config, each without the listed sub-projects nested in it
+func DetectConfigProjects(fsys fs.FS, projects map[string]helpers.ProjectConfig) (DetectedInfo, map[string]DetectedInfo, error) {
+	paths := make([]string, 0, len(projects))
+	for path := range projects {
+		paths = append(paths, filepath.ToSlash(filepath.Clean(path)))
+	}
 
-	// Try major.minor (e.g., 3.11 from 3.11.0)
-	if len(parts) >= 2 {
-		majorMinor := parts[0] + "." + parts[1]
-		if cycles[majorMinor] {
-			return majorMinor
+	root, err := detectors.ProjectFS(fsys, ".", paths)
+	if err != nil {
+		return DetectedInfo{}, nil, err
+	}
+
+	infos := make(map[string]DetectedInfo, len(projects))
+	for path := range projects {
+		sub, err := detectors.ProjectFS(fsys, filepath.ToSlash(filepath.Clean(path)), paths)
+		if err != nil {
+			return DetectedInfo{}, nil, fmt.Errorf("opening %s: %w", path, err)
 		}
+		infos[path] = DetectProjectInfo(sub)
 	}
 
-	// Try major only (e.g., 18 from 18.0.0)
-	if len(parts) >= 1 {
-		major := parts[0]
-		if cycles[major] {
-			return major
+	return DetectProjectInfo(root), infos, nil
+}
+
+// DetectSubProjects walks fsys and detects every sub-project,
+// skipping vendor, node_modules and .git directories. Each sub-project is
+// detected without the sub-projects nested in it.
+func DetectSubProjects(fsys fs.FS) ([]SubProject, error) {
+	paths, err := detectors.FindProjectRoots(fsys)
+	if err != nil {
+		return nil, fmt.Errorf("scanning projects: %w", err)
+	}
+
+	projects := make([]SubProject, 0, len(paths))
+	for _, path := range paths {
+		sub, err := detectors.ProjectFS(fsys, path, paths)
+		if err != nil {
+			return nil, fmt.Errorf("opening %s: %w", path, err)
 		}
+
+		projects = append(projects, SubProject{Path: path, Info: DetectProjectInfo(sub)})
 	}
 
-	// If no match found, return original version
-	return version
+	return projects, nil
 }
 
 func getEOLStatus(product, version string) string {
@@ -230,9 +276,7 @@ func getEOLStatus(product, version string) string {
 		return ""
 	}
 
-	// Map product name to cache key
-	cacheKey := mapProductNameToCacheKey(product)
-	cachedProduct := cache.GetProductByKey(cacheKey, products)
+	cachedProduct := cache.GetProductByKey(product, products)
 	if cachedProduct == nil {
 		// Product not found in cache, return empty string
 		return ""
@@ -254,77 +298,66 @@ func getEOLStatus(product, version string) string {
 }
 
 func PrintDetectedInfo(info DetectedInfo) {
-	hasCandidates := len(info.Ruby) > 0 || len(info.Rails) > 0 || len(info.Node) > 0 ||
-		len(info.Go) > 0 || len(info.Python) > 0 || len(info.Docker) > 0
-
-	if !hasCandidates {
+	if len(info.Products) == 0 && len(info.Docker) == 0 {
 		fmt.Println("\nNo project files detected in current directory")
 		return
 	}
 
 	fmt.Println("\n=== Detected Project Information ====")
 
-	// Print Ruby candidates
-	if len(info.Ruby) > 0 {
-		fmt.Println("Ruby:")
-		for _, candidate := range info.Ruby {
-			fmt.Printf("  - %s (from: %s)\n", candidate.Value, candidate.Source)
-		}
-		fmt.Println()
+	for _, product := range sortedProducts(info) {
+		printCandidates(detectors.DisplayName(product), info.Products[product])
 	}
 
-	// Print Rails candidates
-	if len(info.Rails) > 0 {
-		fmt.Println("Rails:")
-		for _, candidate := range info.Rails {
-			fmt.Printf("  - %s (from: %s)\n", candidate.Value, candidate.Source)
-		}
-		fmt.Println()
+	// Print unclassified Docker candidates (those that don't match any detector)
+	printCandidates("Docker", info.Docker)
+}
+
+// PrintSubProjects prints the detection results of every sub-project
+func PrintSubProjects(projects []SubProject) {
+	if len(projects) == 0 {
+		fmt.Println("\nNo projects detected")
+		return
 	}
 
-	// Print Node candidates
-	if len(info.Node) > 0 {
-		fmt.Println("Node.js:")
-		for _, candidate := range info.Node {
-			fmt.Printf("  - %s (from: %s)\n", candidate.Value, candidate.Source)
-		}
-		fmt.Println()
+	for _, project := range projects {
+		fmt.Printf("\n### Project: %s\n", project.Path)
+		PrintDetectedInfo(project.Info)
 	}
+}
 
-	// Print Go candidates
-	if len(info.Go) > 0 {
-		fmt.Println("Go:")
-		for _, candidate := range info.Go {
-			fmt.Printf("  - %s (from: %s)\n", candidate.Value, candidate.Source)
-		}
-		fmt.Println()
+// sortedProducts returns the product keys of the detection results in a stable order
+func sortedProducts(info DetectedInfo) []string {
+	products := make([]string, 0, len(info.Products))
+	for product := range info.Products {
+		products = append(products, product)
 	}
+	sort.Strings(products)
+	return products
+}
 
-	// Print Python candidates
-	if len(info.Python) > 0 {
-		fmt.Println("Python:")
-		for _, candidate := range info.Python {
-			fmt.Printf("  - %s (from: %s)\n", candidate.Value, candidate.Source)
-		}
-		fmt.Println()
+// printCandidates prints a titled list of candidates, or nothing if the list is empty
+func printCandidates(title string, candidates []detectors.Candidate) {
+	if len(candidates) == 0 {
+		return
 	}
 
-	// Print unclassified Docker candidates (those that don't match Ruby/Python/Node/Go)
-	unclassifiedDocker := []detectors.Candidate{}
-	for _, dockerCandidate := range info.Docker {
-		image := dockerCandidate.Value
-		if !strings.Contains(image, "ruby:") && !strings.Contains(image, "python:") &&
-			!strings.Contains(image, "node:") && !strings.Contains(image, "golang:") &&
-			!strings.Contains(image, "go:") {
-			unclassifiedDocker = append(unclassifiedDocker, dockerCandidate)
-		}
+	fmt.Printf("%s:\n", title)
+	for _, candidate := range candidates {
+		fmt.Printf("  - %s (from: %s%s)\n", candidate.Value, candidate.Source, describeSpec(candidate))
 	}
+	fmt.Println()
+}
 
-	if len(unclassifiedDocker) > 0 {
-		fmt.Println("Docker:")
-		for _, candidate := range unclassifiedDocker {
-			fmt.Printf("  - %s (from: %s)\n", candidate.Value, candidate.Source)
-		}
-		fmt.Println()
+// describeSpec describes the spec a candidate was resolved from when it is not the
+// version itself, e.g. `, spec ">=18" allows 18 to 22`
+func describeSpec(candidate detectors.Candidate) string {
+	var description string
+	if candidate.Spec != "" && candidate.Spec != candidate.Value {
+		description = fmt.Sprintf(", spec %q", candidate.Spec)
+	}
+	if candidate.MinCycle != candidate.MaxCycle {
+		description += fmt.Sprintf(" allows %s to %s", candidate.MinCycle, candidate.MaxCycle)
 	}
+	return description
 }
@@ -2,6 +2,8 @@ package cmd
 
 import (
 	"testing"
+
+	"github.com/stacktodate/stacktodate-cli/cmd/lib/versions"
 )
 
 func TestCleanVersion(t *testing.T) {
@@ -75,6 +77,11 @@ func TestCleanVersion(t *testing.T) {
 			input:    "   ",
 			expected: "",
 		},
+		{
+			name:     "composer caret constraint",
+			input:    "^8.1",
+			expected: "8.1",
+		},
 		{
 			name:     "version with extra text after numbers",
 			input:    "3.11.0-alpine",
@@ -160,10 +167,11 @@ func TestExtractVersionFromDockerImage(t *testing.T) {
 	}
 }
 
-func TestCleanCandidateVersions(t *testing.T) {
+func TestResolveCandidateVersions(t *testing.T) {
 	tests := []struct {
 		name     string
 		input    []Candidate
+		cycles   []string
 		expected []Candidate
 	}{
 		{
@@ -193,19 +201,74 @@ func TestCleanCandidateVersions(t *testing.T) {
 				{Value: "2.0.0", Source: "test"},
 			},
 		},
+		{
+			name: "npm ranges resolved to release cycles",
+			input: []Candidate{
+				{Value: "^18 || ^20", Source: "package.json", Syntax: versions.NPM},
+				{Value: "<21", Source: "package.json", Syntax: versions.NPM},
+			},
+			cycles: []string{"16", "18", "19", "20", "21"},
+			expected: []Candidate{
+				{Value: "18", Source: "package.json", Spec: "^18 || ^20", MinCycle: "18", MaxCycle: "20"},
+				{Value: "20", Source: "package.json", Spec: "<21", MinCycle: "16", MaxCycle: "20"},
+			},
+		},
+		{
+			name: "python specifiers and docker tags resolved to release cycles",
+			input: []Candidate{
+				{Value: ">=3.9,<3.12", Source: "pyproject.toml", Syntax: versions.PEP440},
+				{Value: "3.11", Source: "Dockerfile", Syntax: versions.DockerTag, Spec: "3.11.4-slim"},
+				{Value: "system", Source: ".tool-versions"},
+			},
+			cycles: []string{"3.8", "3.9", "3.10", "3.11", "3.12"},
+			expected: []Candidate{
+				{Value: "3.9", Source: "pyproject.toml", Spec: ">=3.9,<3.12", MinCycle: "3.9", MaxCycle: "3.11"},
+				{Value: "3.11", Source: "Dockerfile", Spec: "3.11.4-slim", MinCycle: "3.11", MaxCycle: "3.11"},
+				{Value: "system", Source: ".tool-versions", Spec: "system"},
+			},
+		},
+		{
+			name: "go release candidate",
+			input: []Candidate{
+				{Value: "go1.22rc1", Source: "go.mod", Syntax: versions.GoToolchain},
+			},
+			cycles: []string{"1.21", "1.22"},
+			expected: []Candidate{
+				{Value: "1.22", Source: "go.mod", Spec: "go1.22rc1", MinCycle: "1.22", MaxCycle: "1.22"},
+			},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := cleanCandidateVersions(tt.input)
+			result := resolveCandidateVersions(tt.input, tt.cycles)
 			if len(result) != len(tt.expected) {
-				t.Errorf("cleanCandidateVersions() returned %d items, want %d", len(result), len(tt.expected))
+				t.Errorf("resolveCandidateVersions() returned %d items, want %d", len(result), len(tt.expected))
 			}
 			for i, candidate := range result {
-				if candidate.Value != tt.expected[i].Value || candidate.Source != tt.expected[i].Source {
-					t.Errorf("cleanCandidateVersions()[%d] = %v, want %v", i, candidate, tt.expected[i])
+				expected := tt.expected[i]
+				if candidate.Value != expected.Value || candidate.Source != expected.Source {
+					t.Errorf("resolveCandidateVersions()[%d] = %v, want %v", i, candidate, expected)
+				}
+				if expected.Spec != "" && (candidate.Spec != expected.Spec || candidate.MinCycle != expected.MinCycle || candidate.MaxCycle != expected.MaxCycle) {
+					t.Errorf("resolveCandidateVersions()[%d] = %v, want spec %q with cycles %q to %q", i, candidate, expected.Spec, expected.MinCycle, expected.MaxCycle)
 				}
 			}
 		})
 	}
 }
+
+func TestImageTag(t *testing.T) {
+	tests := map[string]string{
+		"python:3.11.4-slim":                "3.11.4-slim",
+		"node":                              "",
+		"localhost:5000/myapp":              "",
+		"myregistry.azurecr.io/myapp:v1.0":  "v1.0",
+	}
+
+	for image, expected := range tests {
+		if result := imageTag(image); result != expected {
+			t.Errorf("imageTag(%q) = %q, want %q", image, result, expected)
+		}
+	}
+}
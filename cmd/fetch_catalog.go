@@ -15,12 +15,22 @@ var fetchCatalogCmd = &cobra.Command{
 	Long: `Fetch the complete list of products and their release information from stacktodate.club API
 and store it locally for faster version detection and truncation.
 
-The catalog is cached in ~/.stacktodate/products-cache.json and automatically refreshed
-once every 24 hours. You can use this command to manually refresh the cache at any time.`,
+The catalog is cached in ~/.stacktodate/products-cache.json and automatically revalidated
+once every 24 hours. You can use this command to manually refresh the cache at any time.
+
+Refreshes are conditional: when the catalog has not changed since it was cached, only
+the cache timestamp is renewed. The refresh interval can be changed with --catalog-ttl,
+the STD_CATALOG_TTL environment variable or catalog_ttl in ~/.stacktodate/config.yaml.`,
 	Run: func(cmd *cobra.Command, args []string) {
+		if path := os.Getenv(cache.CatalogFileEnv); path != "" {
+			fmt.Fprintf(os.Stderr, "Catalog is pinned to %s by %s, nothing to fetch\n", path, cache.CatalogFileEnv)
+			return
+		}
+
 		fmt.Fprintf(os.Stderr, "Fetching product catalog from stacktodate.club...\n")
 
-		if err := cache.FetchAndCache(); err != nil {
+		changed, err := cache.FetchAndCache()
+		if err != nil {
 			helpers.ExitOnError(err, "failed to fetch catalog")
 		}
 
@@ -31,7 +41,11 @@ once every 24 hours. You can use this command to manually refresh the cache at a
 		}
 
 		cachePath, _ := cache.GetCachePath()
-		fmt.Fprintf(os.Stderr, "✓ Successfully cached %d products\n", len(products.Products))
+		if changed {
+			fmt.Fprintf(os.Stderr, "✓ Successfully cached %d products (catalog updated)\n", len(products.Products))
+		} else {
+			fmt.Fprintf(os.Stderr, "✓ Catalog unchanged, %d products cached\n", len(products.Products))
+		}
 		fmt.Fprintf(os.Stderr, "Cache location: %s\n", cachePath)
 	},
 }
@@ -3,9 +3,11 @@ package helpers
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 
 	"github.com/stacktodate/stacktodate-cli/cmd/lib/cache"
 )
@@ -30,6 +32,28 @@ func ConvertStackToComponents(stack map[string]StackEntry) []Component {
 	return components
 }
 
+// ConvertConfigToComponents converts the stack of a config and of all its sub-projects
+// to API component format, skipping duplicate name and version pairs
+func ConvertConfigToComponents(config *Config) []Component {
+	components := ConvertStackToComponents(config.Stack)
+
+	seen := make(map[Component]bool)
+	for _, component := range components {
+		seen[component] = true
+	}
+
+	for _, project := range config.Projects {
+		for _, component := range ConvertStackToComponents(project.Stack) {
+			if !seen[component] {
+				seen[component] = true
+				components = append(components, component)
+			}
+		}
+	}
+
+	return components
+}
+
 // TechStackRequest is used for POST /api/tech_stacks
 type TechStackRequest struct {
 	TechStack struct {
@@ -38,17 +62,46 @@ type TechStackRequest struct {
 	} `json:"tech_stack"`
 }
 
-// TechStackResponse is the response from both GET and POST tech stack endpoints
-type TechStackResponse struct {
-	Success   bool   `json:"success,omitempty"`
-	Message   string `json:"message,omitempty"`
+// TechStackUpdateRequest is used for PATCH /api/tech_stacks/:id
+type TechStackUpdateRequest struct {
 	TechStack struct {
-		ID         string      `json:"id"`
-		Name       string      `json:"name"`
-		Components []Component `json:"components"`
+		Name string `json:"name"`
 	} `json:"tech_stack"`
 }
 
+// ComponentsRequest is used for PUT /api/tech_stacks/:id/components
+type ComponentsRequest struct {
+	Components []Component `json:"components"`
+}
+
+// TechStack is a project on the API and its components
+type TechStack struct {
+	ID         string      `json:"id"`
+	Name       string      `json:"name"`
+	Components []Component `json:"components"`
+}
+
+// TechStackResponse is the response from both GET and POST tech stack endpoints
+type TechStackResponse struct {
+	Success   bool      `json:"success,omitempty"`
+	Message   string    `json:"message,omitempty"`
+	TechStack TechStack `json:"tech_stack"`
+}
+
+// TechStackListResponse is the response from GET /api/tech_stacks
+type TechStackListResponse struct {
+	TechStacks []TechStack `json:"tech_stacks"`
+	Meta       Pagination  `json:"meta"`
+}
+
+// Pagination describes the page of a list response
+type Pagination struct {
+	Page       int `json:"page"`
+	PerPage    int `json:"per_page"`
+	TotalPages int `json:"total_pages"`
+	TotalCount int `json:"total_count"`
+}
+
 // CreateTechStack creates a new tech stack on the API
 // Returns the newly created tech stack with UUID
 func CreateTechStack(token, name string, components []Component) (*TechStackResponse, error) {
@@ -93,6 +146,89 @@ func GetTechStack(token, uuid string) (*TechStackResponse, error) {
 	return &response, nil
 }
 
+// ListTechStacks retrieves one page of the tech stacks the token has access to
+func ListTechStacks(token string, page, perPage int) (*TechStackListResponse, error) {
+	apiURL := cache.GetAPIURL()
+	url := fmt.Sprintf("%s/api/tech_stacks?page=%d&per_page=%d", apiURL, page, perPage)
+
+	var response TechStackListResponse
+	if err := makeAPIRequest("GET", url, token, nil, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// RenameTechStack changes the name of an existing tech stack on the API
+func RenameTechStack(token, uuid, name string) (*TechStackResponse, error) {
+	apiURL := cache.GetAPIURL()
+	url := fmt.Sprintf("%s/api/tech_stacks/%s", apiURL, uuid)
+
+	request := TechStackUpdateRequest{}
+	request.TechStack.Name = name
+
+	var response TechStackResponse
+	if err := makeAPIRequest("PATCH", url, token, request, &response); err != nil {
+		return nil, err
+	}
+
+	if !response.Success {
+		return nil, fmt.Errorf("API error: %s", response.Message)
+	}
+
+	return &response, nil
+}
+
+// DeleteTechStack deletes an existing tech stack from the API
+func DeleteTechStack(token, uuid string) error {
+	apiURL := cache.GetAPIURL()
+	url := fmt.Sprintf("%s/api/tech_stacks/%s", apiURL, uuid)
+
+	return makeAPIRequest("DELETE", url, token, nil, nil)
+}
+
+// PushComponents replaces the components of an existing tech stack on the API
+func PushComponents(token, uuid string, components []Component) (*TechStackResponse, error) {
+	apiURL := cache.GetAPIURL()
+	url := fmt.Sprintf("%s/api/tech_stacks/%s/components", apiURL, uuid)
+
+	request := ComponentsRequest{Components: components}
+
+	var response TechStackResponse
+	if err := makeAPIRequest("PUT", url, token, request, &response); err != nil {
+		return nil, err
+	}
+
+	if !response.Success {
+		return nil, fmt.Errorf("API error: %s", response.Message)
+	}
+
+	return &response, nil
+}
+
+// parseValidationError reads the message and field errors of a 422 response. Field errors
+// are either a map of field names to messages or a list of full messages.
+func parseValidationError(body []byte) *ValidationError {
+	var errResp struct {
+		Message string          `json:"message"`
+		Errors  json.RawMessage `json:"errors"`
+	}
+	validationErr := &ValidationError{}
+	if err := json.Unmarshal(body, &errResp); err != nil {
+		return validationErr
+	}
+	validationErr.Message = errResp.Message
+
+	var messages []string
+	if err := json.Unmarshal(errResp.Errors, &validationErr.Fields); err != nil && json.Unmarshal(errResp.Errors, &messages) == nil {
+		if validationErr.Message == "" {
+			validationErr.Message = strings.Join(messages, ", ")
+		}
+	}
+
+	return validationErr
+}
+
 // makeAPIRequest is a private helper that handles common API request logic
 func makeAPIRequest(method, url, token string, requestBody interface{}, response interface{}) error {
 	var req *http.Request
@@ -118,9 +254,12 @@ func makeAPIRequest(method, url, token string, requestBody interface{}, response
 	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
 
 	// Make request
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := HTTPClient().Do(req)
 	if err != nil {
+		var settingsErr *SettingsError
+		if errors.As(err, &settingsErr) {
+			return settingsErr
+		}
 		return fmt.Errorf("failed to connect to StackToDate API: %w\n\nPlease check your internet connection and try again", err)
 	}
 	defer resp.Body.Close()
@@ -133,32 +272,25 @@ func makeAPIRequest(method, url, token string, requestBody interface{}, response
 
 	// Handle error responses first
 	if resp.StatusCode == http.StatusUnauthorized {
-		return fmt.Errorf("authentication failed: invalid or expired token\n\nPlease update your token with: stacktodate global-config set")
+		return &UnauthorizedError{StatusCode: resp.StatusCode, Message: responseMessage(body)}
 	}
 
 	if resp.StatusCode == http.StatusNotFound {
-		return fmt.Errorf("project not found: UUID does not exist\n\nPlease check the UUID or create a new project")
+		return &NotFoundError{StatusCode: resp.StatusCode, Message: responseMessage(body)}
 	}
 
 	if resp.StatusCode == http.StatusUnprocessableEntity {
-		var errResp struct {
-			Message string `json:"message"`
-		}
-		if err := json.Unmarshal(body, &errResp); err == nil && errResp.Message != "" {
-			return fmt.Errorf("validation error: %s", errResp.Message)
-		}
-		return fmt.Errorf("validation error: the server rejected your request")
+		return parseValidationError(body)
 	}
 
-	if resp.StatusCode >= 500 {
-		return fmt.Errorf("StackToDate API is experiencing issues (status %d)\n\nPlease try again later", resp.StatusCode)
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusNoContent {
+		return &APIError{StatusCode: resp.StatusCode, Body: string(body)}
 	}
 
-	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
-		return fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(body))
+	// Parse successful response, if the caller expects one
+	if response == nil || len(bytes.TrimSpace(body)) == 0 {
+		return nil
 	}
-
-	// Parse successful response
 	if err := json.Unmarshal(body, response); err != nil {
 		return fmt.Errorf("failed to parse API response: %w", err)
 	}
@@ -0,0 +1,179 @@
+package helpers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPushComponents(t *testing.T) {
+	var received ComponentsRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/api/tech_stacks/abc/components" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			t.Errorf("Authorization = %q, want the bearer token", r.Header.Get("Authorization"))
+		}
+		json.NewDecoder(r.Body).Decode(&received)
+		fmt.Fprint(w, `{"success":true,"tech_stack":{"id":"abc","name":"Web","components":[{"name":"go","version":"1.22"}]}}`)
+	}))
+	defer server.Close()
+	t.Setenv("STD_API_URL", server.URL)
+
+	response, err := PushComponents("secret", "abc", []Component{{Name: "go", Version: "1.22"}})
+	if err != nil {
+		t.Fatalf("PushComponents: unexpected error: %v", err)
+	}
+	if len(received.Components) != 1 || received.Components[0].Name != "go" {
+		t.Errorf("PushComponents: sent %+v, want the go component", received)
+	}
+	if response.TechStack.ID != "abc" {
+		t.Errorf("PushComponents: got tech stack %q, want abc", response.TechStack.ID)
+	}
+}
+
+func TestAPIErrors(t *testing.T) {
+	defer func(delay time.Duration) { retryBaseDelay = delay }(retryBaseDelay)
+	retryBaseDelay = time.Millisecond
+
+	tests := []struct {
+		name     string
+		status   int
+		body     string
+		check    func(error) bool
+		exitCode int
+		message  string
+	}{
+		{
+			"unauthorized", 401, `{}`,
+			func(err error) bool {
+				var unauthorizedErr *UnauthorizedError
+				return errors.As(err, &unauthorizedErr) && unauthorizedErr.StatusCode == 401 && errors.Is(err, ErrUnauthorized)
+			},
+			ExitUnauthorized, "global-config set",
+		},
+		{
+			"unauthorized with message", 401, `{"error":"Token revoked"}`,
+			func(err error) bool {
+				var unauthorizedErr *UnauthorizedError
+				return errors.As(err, &unauthorizedErr) && unauthorizedErr.Message == "Token revoked"
+			},
+			ExitUnauthorized, "invalid or expired token (Token revoked)",
+		},
+		{
+			"not found", 404, `{}`,
+			func(err error) bool {
+				var notFoundErr *NotFoundError
+				return errors.As(err, &notFoundErr) && notFoundErr.StatusCode == 404 && errors.Is(err, ErrNotFound) && !errors.Is(err, ErrUnauthorized)
+			},
+			ExitNotFound, "UUID does not exist",
+		},
+		{
+			"validation with fields", 422, `{"message":"Invalid components","errors":{"components":["must not be empty"]}}`,
+			func(err error) bool {
+				var validationErr *ValidationError
+				return errors.As(err, &validationErr) && validationErr.Fields["components"][0] == "must not be empty"
+			},
+			ExitValidation, "validation error: Invalid components (components must not be empty)",
+		},
+		{
+			"validation with full messages", 422, `{"errors":["Name can't be blank"]}`,
+			func(err error) bool {
+				var validationErr *ValidationError
+				return errors.As(err, &validationErr)
+			},
+			ExitValidation, "validation error: Name can't be blank",
+		},
+		{
+			"server error", 502, `bad gateway`,
+			func(err error) bool {
+				var apiErr *APIError
+				return errors.As(err, &apiErr) && apiErr.StatusCode == 502
+			},
+			ExitServerError, "experiencing issues",
+		},
+		{
+			"other status", 409, `conflict`,
+			func(err error) bool {
+				var apiErr *APIError
+				return errors.As(err, &apiErr)
+			},
+			1, "API error (status 409): conflict",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				fmt.Fprint(w, tt.body)
+			}))
+			defer server.Close()
+			t.Setenv("STD_API_URL", server.URL)
+
+			_, err := PushComponents("secret", "abc", nil)
+			if err == nil || !tt.check(err) {
+				t.Fatalf("PushComponents: got error %v, want a %s error", err, tt.name)
+			}
+			if code := ExitCode(err); code != tt.exitCode {
+				t.Errorf("ExitCode(%v) = %d, want %d", err, code, tt.exitCode)
+			}
+			if !strings.Contains(err.Error(), tt.message) {
+				t.Errorf("PushComponents: error %q, want it to contain %q", err, tt.message)
+			}
+		})
+	}
+}
+
+func TestManageTechStacks(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests = append(requests, strings.TrimSpace(fmt.Sprintf("%s %s %s", r.Method, r.URL.RequestURI(), body)))
+
+		switch r.Method {
+		case http.MethodGet:
+			fmt.Fprint(w, `{"tech_stacks":[{"id":"abc","name":"Web","components":[]}],"meta":{"page":2,"per_page":1,"total_pages":3,"total_count":3}}`)
+		case http.MethodPatch:
+			fmt.Fprint(w, `{"success":true,"tech_stack":{"id":"abc","name":"Storefront"}}`)
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+	t.Setenv("STD_API_URL", server.URL)
+
+	list, err := ListTechStacks("secret", 2, 1)
+	if err != nil {
+		t.Fatalf("ListTechStacks: unexpected error: %v", err)
+	}
+	if len(list.TechStacks) != 1 || list.TechStacks[0].ID != "abc" || list.Meta.TotalPages != 3 {
+		t.Errorf("ListTechStacks: got %+v, want tech stack abc on page 2 of 3", list)
+	}
+
+	renamed, err := RenameTechStack("secret", "abc", "Storefront")
+	if err != nil || renamed.TechStack.Name != "Storefront" {
+		t.Errorf("RenameTechStack: got %+v, %v, want the renamed tech stack", renamed, err)
+	}
+
+	if err := DeleteTechStack("secret", "abc"); err != nil {
+		t.Errorf("DeleteTechStack: unexpected error: %v", err)
+	}
+
+	expected := []string{
+		"GET /api/tech_stacks?page=2&per_page=1",
+		`PATCH /api/tech_stacks/abc {"tech_stack":{"name":"Storefront"}}`,
+		"DELETE /api/tech_stacks/abc",
+	}
+	if !reflect.DeepEqual(requests, expected) {
+		t.Errorf("requests = %q, want %q", requests, expected)
+	}
+}
@@ -0,0 +1,147 @@
+package helpers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// Errors returned by API calls, to be checked with errors.Is. errors.As gives the
+// *UnauthorizedError or *NotFoundError with the status and message of the response.
+var (
+	ErrUnauthorized = &UnauthorizedError{StatusCode: http.StatusUnauthorized}
+	ErrNotFound     = &NotFoundError{StatusCode: http.StatusNotFound}
+)
+
+// Exit codes of API errors and invalid configuration; 1 is used for other errors
+const (
+	ExitInvalidConfig = 2
+	ExitUnauthorized  = 3
+	ExitNotFound      = 4
+	ExitValidation    = 5
+	ExitServerError   = 6
+)
+
+// UnauthorizedError is returned when the API rejects the token (401)
+type UnauthorizedError struct {
+	StatusCode int
+	Message    string // Message of the response, if the API sent one
+}
+
+func (e *UnauthorizedError) Error() string {
+	return withMessage("authentication failed: invalid or expired token", e.Message) +
+		"\n\nPlease update your token with: stacktodate global-config set"
+}
+
+// Is makes every UnauthorizedError match ErrUnauthorized
+func (e *UnauthorizedError) Is(target error) bool {
+	return target == ErrUnauthorized
+}
+
+// NotFoundError is returned when the project does not exist (404)
+type NotFoundError struct {
+	StatusCode int
+	Message    string // Message of the response, if the API sent one
+}
+
+func (e *NotFoundError) Error() string {
+	return withMessage("project not found: UUID does not exist", e.Message) +
+		"\n\nPlease check the UUID or create a new project"
+}
+
+// Is makes every NotFoundError match ErrNotFound
+func (e *NotFoundError) Is(target error) bool {
+	return target == ErrNotFound
+}
+
+// withMessage appends the message of the API to an error description
+func withMessage(description, message string) string {
+	if message == "" {
+		return description
+	}
+	return fmt.Sprintf("%s (%s)", description, message)
+}
+
+// responseMessage returns the "message" or "error" of a JSON error response
+func responseMessage(body []byte) string {
+	var errResp struct {
+		Message string `json:"message"`
+		Error   string `json:"error"`
+	}
+	if err := json.Unmarshal(body, &errResp); err != nil {
+		return ""
+	}
+	if errResp.Message != "" {
+		return errResp.Message
+	}
+	return errResp.Error
+}
+
+// ValidationError is returned when the API rejects a request as invalid (422), with the
+// messages of each invalid field when the API reports them
+type ValidationError struct {
+	Message string
+	Fields  map[string][]string
+}
+
+func (e *ValidationError) Error() string {
+	message := e.Message
+	if message == "" {
+		message = "the server rejected your request"
+	}
+
+	fields := make([]string, 0, len(e.Fields))
+	for field := range e.Fields {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	details := make([]string, 0, len(fields))
+	for _, field := range fields {
+		details = append(details, fmt.Sprintf("%s %s", field, strings.Join(e.Fields[field], ", ")))
+	}
+	if len(details) > 0 {
+		message += " (" + strings.Join(details, "; ") + ")"
+	}
+
+	return "validation error: " + message
+}
+
+// APIError is returned for other error responses of the API
+type APIError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *APIError) Error() string {
+	if e.StatusCode >= 500 {
+		return fmt.Sprintf("StackToDate API is experiencing issues (status %d)\n\nPlease try again later", e.StatusCode)
+	}
+	return fmt.Sprintf("API error (status %d): %s", e.StatusCode, e.Body)
+}
+
+// ExitCode returns the exit code for an error: one per kind of API error, 1 otherwise
+func ExitCode(err error) int {
+	var unauthorizedErr *UnauthorizedError
+	var notFoundErr *NotFoundError
+	var validationErr *ValidationError
+	var apiErr *APIError
+	var settingsErr *SettingsError
+
+	switch {
+	case errors.As(err, &settingsErr):
+		return ExitInvalidConfig
+	case errors.As(err, &unauthorizedErr):
+		return ExitUnauthorized
+	case errors.As(err, &notFoundErr):
+		return ExitNotFound
+	case errors.As(err, &validationErr):
+		return ExitValidation
+	case errors.As(err, &apiErr) && apiErr.StatusCode >= 500:
+		return ExitServerError
+	}
+	return 1
+}
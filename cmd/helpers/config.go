@@ -9,15 +9,40 @@ import (
 
 // Config represents the stacktodate.yml structure
 type Config struct {
-	UUID  string                `yaml:"uuid"`
-	Name  string                `yaml:"name"`
+	UUID     string                   `yaml:"uuid"`
+	Name     string                   `yaml:"name"`
+	Stack    map[string]StackEntry    `yaml:"stack,omitempty"`
+	Projects map[string]ProjectConfig `yaml:"projects,omitempty"`
+	Policy   *PolicyConfig            `yaml:"policy,omitempty"`
+}
+
+// ProjectConfig represents a sub-project of a multi-project stacktodate.yml,
+// keyed by its directory relative to the config file
+type ProjectConfig struct {
 	Stack map[string]StackEntry `yaml:"stack,omitempty"`
 }
 
+// PolicyConfig represents the policy section of stacktodate.yml used by check
+type PolicyConfig struct {
+	FailOn     []string `yaml:"fail_on,omitempty"`
+	WarnWithin string   `yaml:"warn_within,omitempty"`
+}
+
 // StackEntry represents a single technology entry in the stack
 type StackEntry struct {
-	Version string `yaml:"version"`
+	Version string `yaml:"version"` // An exact version or a constraint such as "~> 3.2", ">=18 <21" or "3.x"
 	Source  string `yaml:"source"`
+	Line    int    `yaml:"-"` // Line in stacktodate.yml when loaded from a config, or in Source when detected
+}
+
+// UnmarshalYAML decodes a stack entry and records its line in the config file
+func (e *StackEntry) UnmarshalYAML(node *yaml.Node) error {
+	type plain StackEntry
+	if err := node.Decode((*plain)(e)); err != nil {
+		return err
+	}
+	e.Line = node.Line
+	return nil
 }
 
 // LoadConfig reads and parses a config file from the given path
@@ -0,0 +1,86 @@
+package helpers
+
+import (
+	"slices"
+	"sort"
+)
+
+// ComponentDiff is the difference between the components of a remote tech stack and the
+// local ones that would replace them
+type ComponentDiff struct {
+	Added     []Component       `json:"added"`
+	Removed   []Component       `json:"removed"`
+	Changed   []ComponentChange `json:"changed"`
+	Unchanged int               `json:"unchanged"`
+}
+
+// ComponentChange is a component whose version changes
+type ComponentChange struct {
+	Name string `json:"name"`
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// Empty reports whether pushing would not change anything
+func (d ComponentDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// DiffComponents compares remote and local components by name. A component with one version
+// on each side is changed; when sub-projects list a component with several versions, the
+// versions only on one side are added or removed.
+func DiffComponents(remote, local []Component) ComponentDiff {
+	diff := ComponentDiff{Added: []Component{}, Removed: []Component{}, Changed: []ComponentChange{}}
+
+	remoteVersions := componentVersions(remote)
+	localVersions := componentVersions(local)
+
+	names := make([]string, 0, len(remoteVersions)+len(localVersions))
+	for name := range remoteVersions {
+		names = append(names, name)
+	}
+	for name := range localVersions {
+		if _, ok := remoteVersions[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		from, to := remoteVersions[name], localVersions[name]
+
+		if len(from) == 1 && len(to) == 1 && from[0] != to[0] {
+			diff.Changed = append(diff.Changed, ComponentChange{Name: name, From: from[0], To: to[0]})
+			continue
+		}
+
+		for _, version := range to {
+			if slices.Contains(from, version) {
+				diff.Unchanged++
+			} else {
+				diff.Added = append(diff.Added, Component{Name: name, Version: version})
+			}
+		}
+		for _, version := range from {
+			if !slices.Contains(to, version) {
+				diff.Removed = append(diff.Removed, Component{Name: name, Version: version})
+			}
+		}
+	}
+
+	return diff
+}
+
+// componentVersions groups the distinct versions of each component by name, sorted
+func componentVersions(components []Component) map[string][]string {
+	versions := make(map[string][]string)
+	for _, component := range components {
+		if !slices.Contains(versions[component.Name], component.Version) {
+			versions[component.Name] = append(versions[component.Name], component.Version)
+		}
+	}
+	for name := range versions {
+		sort.Strings(versions[name])
+	}
+	return versions
+}
@@ -0,0 +1,43 @@
+package helpers
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffComponents(t *testing.T) {
+	remote := []Component{
+		{Name: "go", Version: "1.21"},
+		{Name: "python", Version: "3.9"},
+		{Name: "ruby", Version: "3.3"},
+		{Name: "nodejs", Version: "18"},
+		{Name: "nodejs", Version: "20"},
+	}
+	local := []Component{
+		{Name: "go", Version: "1.22"},
+		{Name: "ruby", Version: "3.3"},
+		{Name: "rails", Version: "7.1"},
+		{Name: "nodejs", Version: "20"},
+		{Name: "nodejs", Version: "22"},
+		{Name: "nodejs", Version: "22"},
+	}
+
+	diff := DiffComponents(remote, local)
+
+	expected := ComponentDiff{
+		Added:     []Component{{Name: "nodejs", Version: "22"}, {Name: "rails", Version: "7.1"}},
+		Removed:   []Component{{Name: "nodejs", Version: "18"}, {Name: "python", Version: "3.9"}},
+		Changed:   []ComponentChange{{Name: "go", From: "1.21", To: "1.22"}},
+		Unchanged: 2,
+	}
+	if !reflect.DeepEqual(diff, expected) {
+		t.Errorf("DiffComponents() = %+v, want %+v", diff, expected)
+	}
+	if diff.Empty() {
+		t.Errorf("DiffComponents().Empty() = true, want false")
+	}
+
+	if diff := DiffComponents(local, local); !diff.Empty() || diff.Unchanged != 5 {
+		t.Errorf("DiffComponents(same) = %+v, want no changes and 5 unchanged", diff)
+	}
+}
@@ -11,10 +11,10 @@ func ExitWithError(exitCode int, format string, args ...interface{}) {
 	os.Exit(exitCode)
 }
 
-// ExitOnError is a convenience wrapper that exits with code 1
+// ExitOnError is a convenience wrapper that exits with code 1, or the exit code of an API error
 func ExitOnError(err error, format string, args ...interface{}) {
 	if err != nil {
 		args = append(args, err)
-		ExitWithError(1, format+": %v", args...)
+		ExitWithError(ExitCode(err), format+": %v", args...)
 	}
 }
@@ -2,6 +2,7 @@ package helpers
 
 import (
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
 )
@@ -30,25 +31,15 @@ func GetConfigDir(configPath string) (string, error) {
 	return dir, nil
 }
 
-// WithWorkingDir executes the given function in the specified directory,
-// then restores the original working directory. Errors from either
-// directory change or the function are returned.
-func WithWorkingDir(targetDir string, fn func() error) error {
-	if targetDir == "." || targetDir == "" {
-		// No need to change directory
-		return fn()
-	}
-
-	originalDir, err := os.Getwd()
+// OpenDir returns a read-only file system rooted at dir
+func OpenDir(dir string) (fs.FS, error) {
+	info, err := os.Stat(dir)
 	if err != nil {
-		return fmt.Errorf("getting current directory: %w", err)
+		return nil, fmt.Errorf("opening directory %s: %w", dir, err)
 	}
-
-	if err := os.Chdir(targetDir); err != nil {
-		return fmt.Errorf("changing to directory %s: %w", targetDir, err)
+	if !info.IsDir() {
+		return nil, fmt.Errorf("opening directory %s: not a directory", dir)
 	}
 
-	defer os.Chdir(originalDir)
-
-	return fn()
+	return os.DirFS(dir), nil
 }
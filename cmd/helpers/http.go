@@ -0,0 +1,282 @@
+package helpers
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"os"
+	"runtime"
+	"strconv"
+	"time"
+
+	"github.com/stacktodate/stacktodate-cli/internal/version"
+)
+
+// Defaults of the shared HTTP client, overridden by STD_HTTP_TIMEOUT, STD_HTTP_RETRIES and
+// STD_CA_BUNDLE or the http_timeout, http_retries and ca_bundle global settings
+const (
+	DefaultHTTPTimeout = 30 * time.Second
+	DefaultHTTPRetries = 3
+)
+
+// retryBaseDelay is the delay before the first retry, doubled on each further one
+var retryBaseDelay = 500 * time.Millisecond
+
+// maxRetryAfter is the longest Retry-After the client waits for; longer waits fail instead
+const maxRetryAfter = time.Minute
+
+// HTTPOptions configures the shared HTTP client
+type HTTPOptions struct {
+	Timeout  time.Duration // Per attempt, including reading the response body
+	Retries  int           // Retries on 429 and 5xx responses
+	CABundle string        // PEM file with extra trusted certificates, e.g. of a corporate proxy
+}
+
+var httpClient *http.Client
+
+// SettingsError is returned by every request of the shared HTTP client when its settings
+// are invalid, so that only commands making requests fail on them
+type SettingsError struct {
+	Err error
+}
+
+func (e *SettingsError) Error() string {
+	return "invalid HTTP settings: " + e.Err.Error()
+}
+
+func (e *SettingsError) Unwrap() error {
+	return e.Err
+}
+
+// HTTPClient returns the HTTP client used for every API call. It is configured once from
+// the environment and global settings; when they are invalid, its requests fail with a
+// *SettingsError.
+func HTTPClient() *http.Client {
+	if httpClient == nil {
+		if _, err := ConfigureHTTPClient(); err != nil {
+			httpClient = &http.Client{Transport: failingTransport{err: &SettingsError{Err: err}}}
+		}
+	}
+	return httpClient
+}
+
+// failingTransport fails every request with the same error
+type failingTransport struct {
+	err error
+}
+
+func (t failingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+	return nil, t.err
+}
+
+// ConfigureHTTPClient builds the shared HTTP client from the environment and global settings
+func ConfigureHTTPClient() (*http.Client, error) {
+	options, err := resolveHTTPOptions()
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := NewHTTPClient(options)
+	if err != nil {
+		return nil, err
+	}
+	httpClient = client
+	return client, nil
+}
+
+// resolveHTTPOptions reads the HTTP options from environment variables, then the global settings
+func resolveHTTPOptions() (HTTPOptions, error) {
+	options := HTTPOptions{Timeout: DefaultHTTPTimeout, Retries: DefaultHTTPRetries}
+
+	settings, err := LoadGlobalSettings()
+	if err != nil {
+		return options, err
+	}
+
+	if value := GetEnvOrDefault("STD_HTTP_TIMEOUT", settings.HTTPTimeout); value != "" {
+		timeout, err := time.ParseDuration(value)
+		if err != nil || timeout <= 0 {
+			return options, fmt.Errorf("invalid HTTP timeout %q (expected e.g. 10s or 1m)", value)
+		}
+		options.Timeout = timeout
+	}
+
+	if value := os.Getenv("STD_HTTP_RETRIES"); value != "" {
+		retries, err := strconv.Atoi(value)
+		if err != nil || retries < 0 {
+			return options, fmt.Errorf("invalid STD_HTTP_RETRIES %q (expected a number of retries)", value)
+		}
+		options.Retries = retries
+	} else if settings.HTTPRetries != nil {
+		options.Retries = *settings.HTTPRetries
+	}
+
+	options.CABundle = GetEnvOrDefault("STD_CA_BUNDLE", settings.CABundle)
+
+	return options, nil
+}
+
+// NewHTTPClient returns a client that goes through HTTPS_PROXY/HTTP_PROXY (honouring NO_PROXY),
+// trusts the CA bundle in addition to the system roots, sends a versioned User-Agent and
+// retries on 429 and 5xx responses with exponential backoff
+func NewHTTPClient(options HTTPOptions) (*http.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = http.ProxyFromEnvironment
+
+	if options.CABundle != "" {
+		pool, err := loadCABundle(options.CABundle)
+		if err != nil {
+			return nil, err
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+	}
+
+	return &http.Client{
+		Transport: &retryTransport{
+			base:    transport,
+			timeout: options.Timeout,
+			retries: options.Retries,
+		},
+	}, nil
+}
+
+// UserAgent returns the User-Agent sent with every request, e.g. "stacktodate-cli/1.4.0 (linux/amd64)"
+func UserAgent() string {
+	return fmt.Sprintf("stacktodate-cli/%s (%s/%s)", version.GetVersion(), runtime.GOOS, runtime.GOARCH)
+}
+
+// loadCABundle returns the system roots with the certificates of a PEM file added
+func loadCABundle(path string) (*x509.CertPool, error) {
+	pem, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA bundle: %w", err)
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificates found in CA bundle %s", path)
+	}
+	return pool, nil
+}
+
+// retryTransport applies a timeout to each attempt, sets the User-Agent and retries
+// requests that were rate limited or failed on the server
+type retryTransport struct {
+	base    http.RoundTripper
+	timeout time.Duration
+	retries int
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		resp, err := t.roundTrip(req, attempt)
+		if err != nil || attempt >= t.retries || !shouldRetry(req, resp) {
+			return resp, err
+		}
+
+		delay, ok := retryDelay(resp, attempt)
+		if !ok {
+			return resp, nil
+		}
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+
+		select {
+		case <-time.After(delay):
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+	}
+}
+
+// roundTrip sends one attempt of the request with its own timeout, which only ends once
+// the response body is closed
+func (t *retryTransport) roundTrip(req *http.Request, attempt int) (*http.Response, error) {
+	ctx, cancel := req.Context(), context.CancelFunc(func() {})
+	if t.timeout > 0 {
+		ctx, cancel = context.WithTimeout(req.Context(), t.timeout)
+	}
+
+	r := req.Clone(ctx)
+	if r.Header.Get("User-Agent") == "" {
+		r.Header.Set("User-Agent", UserAgent())
+	}
+	if attempt > 0 && req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+		r.Body = body
+	}
+
+	resp, err := t.base.RoundTrip(r)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// shouldRetry reports whether a response is worth retrying. Rate limited (429) and
+// unavailable (503) requests were not processed and are retried for every method;
+// other server errors only for idempotent methods.
+func shouldRetry(req *http.Request, resp *http.Response) bool {
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests, resp.StatusCode == http.StatusServiceUnavailable:
+		return true
+	case resp.StatusCode >= 500:
+		return req.Method != http.MethodPost && req.Method != http.MethodPatch
+	}
+	return false
+}
+
+// retryDelay returns how long to wait before the next attempt: the Retry-After of the
+// response when it has one, otherwise exponential backoff with jitter. It returns false
+// when the server asks to wait longer than maxRetryAfter.
+func retryDelay(resp *http.Response, attempt int) (time.Duration, bool) {
+	if value := resp.Header.Get("Retry-After"); value != "" {
+		var delay time.Duration
+		if seconds, err := strconv.Atoi(value); err == nil {
+			delay = time.Duration(seconds) * time.Second
+		} else if date, err := http.ParseTime(value); err == nil {
+			delay = time.Until(date)
+		}
+		if delay > maxRetryAfter {
+			return 0, false
+		}
+		if delay > 0 {
+			return delay, true
+		}
+	}
+
+	delay := retryBaseDelay << attempt
+	return delay + rand.N(delay/2+1), true
+}
+
+// cancelOnClose releases the context of an attempt once its response body is closed
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c *cancelOnClose) Close() error {
+	err := c.ReadCloser.Close()
+	c.cancel()
+	return err
+}
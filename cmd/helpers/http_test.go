@@ -0,0 +1,157 @@
+package helpers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHTTPClientRetries(t *testing.T) {
+	defer func(delay time.Duration) { retryBaseDelay = delay }(retryBaseDelay)
+	retryBaseDelay = time.Millisecond
+
+	tests := []struct {
+		name       string
+		method     string
+		statuses   []int
+		retryAfter string
+		expected   int
+		requests   int
+	}{
+		{"succeeds after server errors", http.MethodGet, []int{502, 503, 200}, "", 200, 3},
+		{"rate limited", http.MethodPut, []int{429, 200}, "0", 200, 2},
+		{"gives up after the retries", http.MethodGet, []int{500, 500, 500, 500, 200}, "", 500, 4},
+		{"post is not retried on server errors", http.MethodPost, []int{500, 200}, "", 500, 1},
+		{"post is retried when unavailable", http.MethodPost, []int{503, 201}, "", 201, 2},
+		{"client errors are not retried", http.MethodGet, []int{404, 200}, "", 404, 1},
+		{"retry after too long", http.MethodGet, []int{429, 200}, "3600", 429, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				status := tt.statuses[requests]
+				requests++
+
+				if r.Method != http.MethodGet {
+					body, err := io.ReadAll(r.Body)
+					if err != nil || string(body) != `{"name":"x"}` {
+						t.Errorf("request %d: expected the body to be resent, got %q", requests, body)
+					}
+				}
+				if tt.retryAfter != "" {
+					w.Header().Set("Retry-After", tt.retryAfter)
+				}
+				w.WriteHeader(status)
+			}))
+			defer server.Close()
+
+			client, err := NewHTTPClient(HTTPOptions{Timeout: time.Second, Retries: 3})
+			if err != nil {
+				t.Fatalf("NewHTTPClient: unexpected error: %v", err)
+			}
+
+			req, _ := http.NewRequest(tt.method, server.URL, nil)
+			if tt.method != http.MethodGet {
+				req, _ = http.NewRequest(tt.method, server.URL, strings.NewReader(`{"name":"x"}`))
+			}
+
+			resp, err := client.Do(req)
+			if err != nil {
+				t.Fatalf("Do: unexpected error: %v", err)
+			}
+			resp.Body.Close()
+
+			if resp.StatusCode != tt.expected || requests != tt.requests {
+				t.Errorf("Do: got status %d after %d requests, want %d after %d", resp.StatusCode, requests, tt.expected, tt.requests)
+			}
+		})
+	}
+}
+
+func TestHTTPClientTimeoutAndUserAgent(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.Header.Get("User-Agent"), "stacktodate-cli/") {
+			t.Errorf("User-Agent = %q, want a stacktodate-cli User-Agent", r.Header.Get("User-Agent"))
+		}
+		if r.URL.Path == "/slow" {
+			time.Sleep(200 * time.Millisecond)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewHTTPClient(HTTPOptions{Timeout: 50 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("NewHTTPClient: unexpected error: %v", err)
+	}
+
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("Get: unexpected error: %v", err)
+	}
+	resp.Body.Close()
+
+	if _, err := client.Get(server.URL + "/slow"); err == nil {
+		t.Errorf("Get: expected a timeout")
+	}
+}
+
+func TestResolveHTTPOptions(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	t.Setenv("STD_HTTP_TIMEOUT", "5s")
+	t.Setenv("STD_HTTP_RETRIES", "0")
+	t.Setenv("STD_CA_BUNDLE", "/etc/corp-ca.pem")
+	options, err := resolveHTTPOptions()
+	if err != nil || options.Timeout != 5*time.Second || options.Retries != 0 || options.CABundle != "/etc/corp-ca.pem" {
+		t.Errorf("resolveHTTPOptions() = %+v, %v, want 5s, 0 retries and the CA bundle", options, err)
+	}
+
+	t.Setenv("STD_HTTP_TIMEOUT", "soon")
+	if _, err := resolveHTTPOptions(); err == nil {
+		t.Errorf("resolveHTTPOptions: expected an error for an invalid timeout")
+	}
+
+	t.Setenv("STD_HTTP_TIMEOUT", "")
+	t.Setenv("STD_HTTP_RETRIES", "")
+	options, err = resolveHTTPOptions()
+	if err != nil || options.Timeout != DefaultHTTPTimeout || options.Retries != DefaultHTTPRetries {
+		t.Errorf("resolveHTTPOptions() = %+v, %v, want the defaults", options, err)
+	}
+}
+
+func TestHTTPClientInvalidSettings(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("STD_HTTP_TIMEOUT", "soon")
+
+	original := httpClient
+	defer func() { httpClient = original }()
+	httpClient = nil
+
+	// Building the client succeeds, only its requests fail
+	client := HTTPClient()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request with invalid HTTP settings")
+	}))
+	defer server.Close()
+
+	_, err := client.Get(server.URL)
+	var settingsErr *SettingsError
+	if !errors.As(err, &settingsErr) {
+		t.Fatalf("HTTPClient().Get: expected a *SettingsError, got %v", err)
+	}
+	if code := ExitCode(err); code != ExitInvalidConfig {
+		t.Errorf("ExitCode(%v) = %d, want %d", err, code, ExitInvalidConfig)
+	}
+}
+
+func TestNewHTTPClientCABundle(t *testing.T) {
+	if _, err := NewHTTPClient(HTTPOptions{CABundle: "/nonexistent/ca.pem"}); err == nil {
+		t.Errorf("NewHTTPClient: expected an error for a missing CA bundle")
+	}
+}
@@ -0,0 +1,36 @@
+package helpers
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// daysPerUnit maps the suffixes accepted by ParseDays to a number of days
+var daysPerUnit = map[string]int{
+	"d": 1,
+	"w": 7,
+	"m": 30,
+	"y": 365,
+}
+
+// ParseDays parses a time window such as 90d, 12w, 6m or 1y into a number of days.
+// A bare number is read as days.
+func ParseDays(window string) (int, error) {
+	window = strings.ToLower(strings.TrimSpace(window))
+	if window == "" {
+		return 0, nil
+	}
+
+	number, unit := window, 1
+	if multiplier, ok := daysPerUnit[window[len(window)-1:]]; ok {
+		number, unit = window[:len(window)-1], multiplier
+	}
+
+	count, err := strconv.Atoi(number)
+	if err != nil || count < 0 {
+		return 0, fmt.Errorf("invalid time window %q (expected e.g. 90d, 12w, 6m or 1y)", window)
+	}
+
+	return count * unit, nil
+}
@@ -0,0 +1,60 @@
+package helpers
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// GlobalSettings represents the global config file, ~/.stacktodate/config.yaml
+type GlobalSettings struct {
+	CatalogTTL  string `yaml:"catalog_ttl,omitempty"`  // How long the cached catalog is used, e.g. 12h or 7d
+	HTTPTimeout string `yaml:"http_timeout,omitempty"` // Timeout of each API request attempt, e.g. 30s
+	HTTPRetries *int   `yaml:"http_retries,omitempty"` // Retries on 429 and 5xx responses
+	CABundle    string `yaml:"ca_bundle,omitempty"`    // PEM file with extra trusted certificates
+}
+
+// GetGlobalSettingsPath returns the path of the global config file
+func GetGlobalSettingsPath() string {
+	return filepath.Join(getConfigDir(), "config.yaml")
+}
+
+// LoadGlobalSettings reads the global config file, returning empty settings if it does not exist
+func LoadGlobalSettings() (*GlobalSettings, error) {
+	var settings GlobalSettings
+
+	content, err := os.ReadFile(GetGlobalSettingsPath())
+	if os.IsNotExist(err) {
+		return &settings, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read global config: %w", err)
+	}
+
+	if err := yaml.Unmarshal(content, &settings); err != nil {
+		return nil, fmt.Errorf("failed to parse global config %s: %w", GetGlobalSettingsPath(), err)
+	}
+
+	return &settings, nil
+}
+
+// ParseTTL parses a duration such as 30m or 12h, or a number of days or weeks such as 7d or 2w
+func ParseTTL(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if ttl, err := time.ParseDuration(value); err == nil && ttl >= 0 {
+		return ttl, nil
+	}
+
+	// ParseDays reads "m" as months, so only days and weeks are accepted here
+	if lower := strings.ToLower(value); strings.HasSuffix(lower, "d") || strings.HasSuffix(lower, "w") {
+		if days, err := ParseDays(value); err == nil {
+			return time.Duration(days) * 24 * time.Hour, nil
+		}
+	}
+
+	return 0, fmt.Errorf("invalid TTL %q (expected e.g. 30m, 12h or 7d)", value)
+}
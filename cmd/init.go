@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 
@@ -17,13 +18,19 @@ var (
 	name           string
 	skipAutodetect bool
 	noInteractive  bool
+	initRecursive  bool
+	initPerProject bool
 )
 
 var initCmd = &cobra.Command{
 	Use:   "init [path]",
 	Short: "Initialize a new project",
-	Long:  `Initialize a new project with default configuration`,
-	Args:  cobra.MaximumNArgs(1),
+	Long: `Initialize a new project with default configuration.
+
+With --recursive, every sub-project found below the path is detected. By default a
+single stacktodate.yml listing each sub-project under "projects" is written to the
+path; with --per-project, one stacktodate.yml is written in each sub-project instead.`,
+	Args: cobra.MaximumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		reader := bufio.NewReader(os.Stdin)
 
@@ -47,60 +54,26 @@ var initCmd = &cobra.Command{
 
 		fmt.Printf("Initializing project in: %s\n", targetDir)
 
+		if initRecursive {
+			initRecursively(reader, targetDir, token)
+			return
+		}
+
 		// Detect project information in target directory
 		var detectedTechs map[string]helpers.StackEntry
 		if !skipAutodetect {
-			err := helpers.WithWorkingDir(targetDir, func() error {
-				info := DetectProjectInfo()
-				PrintDetectedInfo(info)
-				detectedTechs = selectCandidates(reader, info)
-				return nil
-			})
+			fsys, err := helpers.OpenDir(targetDir)
 			if err != nil {
 				helpers.ExitOnError(err, "failed to detect project")
 			}
-		}
 
-		// NEW: Menu-based project selection (create new or link existing)
-		var projUUID, projName string
-		if uuid == "" && name == "" {
-			// Interactive mode: prompt user for choice
-			choice := promptProjectChoice(reader)
-
-			if choice == 1 {
-				// Create new project on API
-				var createErr error
-				projUUID, projName, createErr = createNewProject(reader, detectedTechs, token)
-				if createErr != nil {
-					helpers.ExitOnError(createErr, "failed to create project")
-				}
-			} else {
-				// Link to existing project on API
-				var linkErr error
-				projUUID, projName, linkErr = linkExistingProject(reader, token)
-				if linkErr != nil {
-					helpers.ExitOnError(linkErr, "failed to link project")
-				}
-			}
-		} else {
-			// Non-interactive mode: use provided flags or fallback to old prompts
-			if uuid == "" {
-				fmt.Print("Enter UUID: ")
-				input, _ := reader.ReadString('\n')
-				projUUID = strings.TrimSpace(input)
-			} else {
-				projUUID = uuid
-			}
-
-			if name == "" {
-				fmt.Print("Enter name: ")
-				input, _ := reader.ReadString('\n')
-				projName = strings.TrimSpace(input)
-			} else {
-				projName = name
-			}
+			info := DetectProjectInfo(fsys)
+			PrintDetectedInfo(info)
+			detectedTechs = selectCandidates(reader, info)
 		}
 
+		projUUID, projName := resolveProject(reader, helpers.ConvertStackToComponents(detectedTechs), token)
+
 		// Create config
 		config := helpers.Config{
 			UUID:  projUUID,
@@ -108,29 +81,149 @@ var initCmd = &cobra.Command{
 			Stack: detectedTechs,
 		}
 
-		// Marshal to YAML
-		data, err := yaml.Marshal(&config)
+		writeInitConfig("stacktodate.yml", config)
+	},
+}
+
+// initRecursively detects every sub-project below targetDir and writes either a single
+// multi-project stacktodate.yml or one stacktodate.yml per sub-project
+func initRecursively(reader *bufio.Reader, targetDir, token string) {
+	if initPerProject && (uuid != "" || name != "") {
+		helpers.ExitWithError(1, "--uuid and --name cannot be used with --per-project")
+	}
+	// Sub-projects are only known from detection, so there would be nothing to write
+	if initPerProject && skipAutodetect {
+		helpers.ExitWithError(1, "--skip-autodetect cannot be used with --per-project")
+	}
+
+	var projects []SubProject
+	if !skipAutodetect {
+		fsys, err := helpers.OpenDir(targetDir)
 		if err != nil {
-			helpers.ExitOnError(err, "failed to create configuration")
+			helpers.ExitOnError(err, "failed to detect projects")
 		}
 
-		// Write to file
-		err = os.WriteFile("stacktodate.yml", data, 0644)
+		projects, err = DetectSubProjects(fsys)
 		if err != nil {
-			helpers.ExitOnError(err, "failed to write stacktodate.yml")
+			helpers.ExitOnError(err, "failed to detect projects")
 		}
+	}
 
-		fmt.Println("\nProject initialized successfully!")
-		fmt.Println("Created stacktodate.yml with:")
-		fmt.Printf("  UUID: %s\n", projUUID)
-		fmt.Printf("  Name: %s\n", projName)
-		if len(detectedTechs) > 0 {
-			fmt.Println("  Stack:")
-			for tech, entry := range detectedTechs {
-				fmt.Printf("    %s: %s (from: %s)\n", tech, entry.Version, entry.Source)
+	if initPerProject {
+		for _, project := range projects {
+			fmt.Printf("\n### Project: %s\n", project.Path)
+			PrintDetectedInfo(project.Info)
+			stack := selectCandidates(reader, project.Info)
+
+			projUUID, projName := resolveProject(reader, helpers.ConvertStackToComponents(stack), token)
+			config := helpers.Config{
+				UUID:  projUUID,
+				Name:  projName,
+				Stack: stack,
 			}
+
+			writeInitConfig(filepath.Join(targetDir, project.Path, "stacktodate.yml"), config)
 		}
-	},
+		return
+	}
+
+	config := helpers.Config{}
+	for _, project := range projects {
+		fmt.Printf("\n### Project: %s\n", project.Path)
+		PrintDetectedInfo(project.Info)
+		stack := selectCandidates(reader, project.Info)
+
+		if project.Path == "." {
+			config.Stack = stack
+			continue
+		}
+		if config.Projects == nil {
+			config.Projects = make(map[string]helpers.ProjectConfig)
+		}
+		config.Projects[project.Path] = helpers.ProjectConfig{Stack: stack}
+	}
+
+	config.UUID, config.Name = resolveProject(reader, helpers.ConvertConfigToComponents(&config), token)
+
+	writeInitConfig(filepath.Join(targetDir, "stacktodate.yml"), config)
+}
+
+// resolveProject returns the UUID and name of the project, taken from the flags or by
+// creating a new project or linking an existing one on the API
+func resolveProject(reader *bufio.Reader, components []helpers.Component, token string) (projUUID, projName string) {
+	// Menu-based project selection (create new or link existing)
+	if uuid == "" && name == "" {
+		// Interactive mode: prompt user for choice
+		choice := promptProjectChoice(reader)
+
+		if choice == 1 {
+			// Create new project on API
+			var createErr error
+			projUUID, projName, createErr = createNewProject(reader, components, token)
+			if createErr != nil {
+				helpers.ExitOnError(createErr, "failed to create project")
+			}
+		} else {
+			// Link to existing project on API
+			var linkErr error
+			projUUID, projName, linkErr = linkExistingProject(reader, token)
+			if linkErr != nil {
+				helpers.ExitOnError(linkErr, "failed to link project")
+			}
+		}
+		return projUUID, projName
+	}
+
+	// Non-interactive mode: use provided flags or fallback to old prompts
+	if uuid == "" {
+		fmt.Print("Enter UUID: ")
+		input, _ := reader.ReadString('\n')
+		projUUID = strings.TrimSpace(input)
+	} else {
+		projUUID = uuid
+	}
+
+	if name == "" {
+		fmt.Print("Enter name: ")
+		input, _ := reader.ReadString('\n')
+		projName = strings.TrimSpace(input)
+	} else {
+		projName = name
+	}
+
+	return projUUID, projName
+}
+
+// writeInitConfig writes the config to path and prints a summary of it
+func writeInitConfig(path string, config helpers.Config) {
+	// Marshal to YAML
+	data, err := yaml.Marshal(&config)
+	if err != nil {
+		helpers.ExitOnError(err, "failed to create configuration")
+	}
+
+	// Write to file
+	err = os.WriteFile(path, data, 0644)
+	if err != nil {
+		helpers.ExitOnError(err, "failed to write %s", path)
+	}
+
+	fmt.Println("\nProject initialized successfully!")
+	fmt.Printf("Created %s with:\n", path)
+	fmt.Printf("  UUID: %s\n", config.UUID)
+	fmt.Printf("  Name: %s\n", config.Name)
+	if len(config.Stack) > 0 {
+		fmt.Println("  Stack:")
+		for tech, entry := range config.Stack {
+			fmt.Printf("    %s: %s (from: %s)\n", tech, entry.Version, entry.Source)
+		}
+	}
+	for path, project := range config.Projects {
+		fmt.Printf("  Project %s:\n", path)
+		for tech, entry := range project.Stack {
+			fmt.Printf("    %s: %s (from: %s)\n", tech, entry.Version, entry.Source)
+		}
+	}
 }
 
 // promptProjectChoice displays a menu for choosing between creating a new project or linking an existing one
@@ -155,7 +248,7 @@ func promptProjectChoice(reader *bufio.Reader) int {
 }
 
 // createNewProject prompts for project name and creates a new project via API
-func createNewProject(reader *bufio.Reader, detectedTechs map[string]helpers.StackEntry, token string) (uuid, projName string, err error) {
+func createNewProject(reader *bufio.Reader, components []helpers.Component, token string) (uuid, projName string, err error) {
 	fmt.Print("\nEnter project name: ")
 	input, _ := reader.ReadString('\n')
 	projName = strings.TrimSpace(input)
@@ -164,9 +257,6 @@ func createNewProject(reader *bufio.Reader, detectedTechs map[string]helpers.Sta
 		return "", "", fmt.Errorf("project name cannot be empty")
 	}
 
-	// Convert detected technologies to API components
-	components := helpers.ConvertStackToComponents(detectedTechs)
-
 	if len(components) == 0 {
 		fmt.Println("⚠️  Warning: No technologies detected")
 		fmt.Println("You can add them later by editing stacktodate.yml and running 'stacktodate push'")
@@ -216,43 +306,15 @@ func linkExistingProject(reader *bufio.Reader, token string) (projUUID, projName
 func selectCandidates(reader *bufio.Reader, info DetectedInfo) map[string]helpers.StackEntry {
 	selected := make(map[string]helpers.StackEntry)
 
-	// Ruby
-	if len(info.Ruby) > 0 {
-		choice := selectFromCandidates(reader, "ruby", info.Ruby)
-		if choice.Version != "" {
-			selected["ruby"] = choice
-		}
-	}
-
-	// Rails
-	if len(info.Rails) > 0 {
-		choice := selectFromCandidates(reader, "rails", info.Rails)
-		if choice.Version != "" {
-			selected["rails"] = choice
-		}
-	}
-
-	// Node.js
-	if len(info.Node) > 0 {
-		choice := selectFromCandidates(reader, "nodejs", info.Node)
-		if choice.Version != "" {
-			selected["nodejs"] = choice
-		}
-	}
-
-	// Go
-	if len(info.Go) > 0 {
-		choice := selectFromCandidates(reader, "go", info.Go)
-		if choice.Version != "" {
-			selected["go"] = choice
+	for _, product := range sortedProducts(info) {
+		candidates := info.Products[product]
+		if len(candidates) == 0 {
+			continue
 		}
-	}
 
-	// Python
-	if len(info.Python) > 0 {
-		choice := selectFromCandidates(reader, "python", info.Python)
+		choice := selectFromCandidates(reader, product, candidates)
 		if choice.Version != "" {
-			selected["python"] = choice
+			selected[product] = choice
 		}
 	}
 
@@ -271,7 +333,7 @@ func selectFromCandidates(reader *bufio.Reader, tech string, candidates []Candid
 
 	fmt.Printf("\nSelect %s version (or press Enter to skip):\n", tech)
 	for i, candidate := range candidates {
-		fmt.Printf("  %d) %s (from: %s)\n", i+1, candidate.Value, candidate.Source)
+		fmt.Printf("  %d) %s (from: %s%s)\n", i+1, candidate.Value, candidate.Source, describeSpec(candidate))
 	}
 	fmt.Printf("  0) Skip\n")
 
@@ -302,4 +364,6 @@ func init() {
 	initCmd.Flags().StringVarP(&name, "name", "n", "", "Name of the project")
 	initCmd.Flags().BoolVar(&skipAutodetect, "skip-autodetect", false, "Skip autodetection of project technologies")
 	initCmd.Flags().BoolVar(&noInteractive, "no-interactive", false, "Use first candidate by default without prompting")
+	initCmd.Flags().BoolVarP(&initRecursive, "recursive", "r", false, "Detect every sub-project below the path (monorepos)")
+	initCmd.Flags().BoolVar(&initPerProject, "per-project", false, "With --recursive, write one stacktodate.yml per sub-project")
 }
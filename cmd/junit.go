@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+)
+
+// junitTestSuites is the JUnit XML report written by check
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	Skipped   *junitSkipped `xml:"skipped,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+}
+
+type junitSkipped struct {
+	Message string `xml:"message,attr"`
+}
+
+// buildJUnit turns every technology into a test case: matched entries pass, mismatched
+// and missing entries fail, and untracked and EOL entries fail when the policy fails on
+// them or are skipped when they are only a warning
+func buildJUnit(result CheckResult) junitTestSuites {
+	cases := make(map[string]*junitTestCase)
+	testCase := func(name string) *junitTestCase {
+		if cases[name] == nil {
+			cases[name] = &junitTestCase{Name: name, ClassName: "stacktodate"}
+		}
+		return cases[name]
+	}
+
+	for _, entry := range result.Results.Matched {
+		testCase(entry.Name)
+	}
+	for _, entry := range result.Results.Mismatched {
+		testCase(entry.Name).Failure = &junitFailure{
+			Message: fmt.Sprintf("detected %s in %s, stacktodate.yml has %s", entry.Detected, entry.Source, entry.Version),
+			Type:    entry.RuleID,
+		}
+	}
+	for _, entry := range result.Results.MissingConfig {
+		testCase(entry.Name).Failure = &junitFailure{
+			Message: fmt.Sprintf("%s is in stacktodate.yml but was not detected", entry.Version),
+			Type:    entry.RuleID,
+		}
+	}
+	for _, entry := range result.Results.Untracked {
+		tc := testCase(entry.Name)
+		message := fmt.Sprintf("detected %s in %s, not listed in stacktodate.yml", entry.Detected, entry.Source)
+		if entry.Severity == severityError {
+			tc.Failure = &junitFailure{Message: message, Type: entry.RuleID}
+		} else {
+			tc.Skipped = &junitSkipped{Message: message}
+		}
+	}
+	for _, entry := range result.Results.EOL {
+		tc := testCase(entry.Name)
+		message := fmt.Sprintf("%s %s", entry.Version, describeEOL(entry))
+		if entry.Severity == severityError {
+			// Keep a version mismatch as the primary failure
+			if tc.Failure == nil {
+				tc.Failure = &junitFailure{Message: message, Type: entry.RuleID}
+			}
+		} else if tc.Failure == nil {
+			tc.Skipped = &junitSkipped{Message: message}
+		}
+	}
+
+	names := make([]string, 0, len(cases))
+	for name := range cases {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	suite := junitTestSuite{Name: "stacktodate check", TestCases: []junitTestCase{}}
+	for _, name := range names {
+		tc := cases[name]
+		suite.Tests++
+		if tc.Failure != nil {
+			suite.Failures++
+		} else if tc.Skipped != nil {
+			suite.Skipped++
+		}
+		suite.TestCases = append(suite.TestCases, *tc)
+	}
+
+	return junitTestSuites{
+		Name:     "stacktodate",
+		Tests:    suite.Tests,
+		Failures: suite.Failures,
+		Skipped:  suite.Skipped,
+		Suites:   []junitTestSuite{suite},
+	}
+}
+
+func outputJUnit(w io.Writer, result CheckResult) {
+	data, err := xml.MarshalIndent(buildJUnit(result), "", "  ")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error marshaling JUnit XML: %v\n", err)
+		os.Exit(2)
+	}
+	fmt.Fprint(w, xml.Header)
+	fmt.Fprintln(w, string(data))
+}
@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/xml"
+	"testing"
+)
+
+func TestBuildJUnit(t *testing.T) {
+	result := CheckResult{
+		Results: CheckResults{
+			Matched: []ComparisonEntry{
+				{Name: "ruby", Version: "3.3", Detected: "3.3", RuleID: ruleVersionMatch},
+				{Name: "nodejs", Version: "20", Detected: "20", RuleID: ruleVersionMatch},
+				{Name: "go", Version: "1.21", Detected: "1.21", RuleID: ruleVersionMatch},
+			},
+			Mismatched: []ComparisonEntry{
+				{Name: "rails", Version: "7.1", Detected: "7.0", Source: "Gemfile.lock", RuleID: ruleVersionMismatch},
+			},
+			MissingConfig: []ComparisonEntry{
+				{Name: "python", Version: "3.11", RuleID: ruleMissing},
+			},
+			EOL: []EOLEntry{
+				{Name: "nodejs", Version: "20", Status: "eol_soon", EOLDate: "2026-04-30", RuleID: ruleEOLSoon, Severity: severityWarning},
+				{Name: "go", Version: "1.21", Status: "eol", EOLDate: "2024-08-13", RuleID: ruleEOL, Severity: severityError},
+			},
+		},
+	}
+
+	report := buildJUnit(result)
+
+	if report.Tests != 5 || report.Failures != 3 || report.Skipped != 1 {
+		t.Errorf("buildJUnit: expected 5 tests, 3 failures, 1 skipped, got %d, %d, %d", report.Tests, report.Failures, report.Skipped)
+	}
+
+	expected := []struct {
+		name    string
+		failure string
+		skipped bool
+	}{
+		{"go", ruleEOL, false},
+		{"nodejs", "", true},
+		{"python", ruleMissing, false},
+		{"rails", ruleVersionMismatch, false},
+		{"ruby", "", false},
+	}
+
+	cases := report.Suites[0].TestCases
+	if len(cases) != len(expected) {
+		t.Fatalf("buildJUnit: expected %d test cases, got %d", len(expected), len(cases))
+	}
+	for i, e := range expected {
+		tc := cases[i]
+		if tc.Name != e.name {
+			t.Errorf("buildJUnit: expected test case %s at index %d, got %s", e.name, i, tc.Name)
+		}
+		if (tc.Failure == nil) != (e.failure == "") || (tc.Failure != nil && tc.Failure.Type != e.failure) {
+			t.Errorf("buildJUnit: unexpected failure for %s: %+v", e.name, tc.Failure)
+		}
+		if (tc.Skipped != nil) != e.skipped {
+			t.Errorf("buildJUnit: expected skipped=%v for %s", e.skipped, e.name)
+		}
+	}
+}
+
+func TestOutputJUnitIsValidXML(t *testing.T) {
+	var buf bytes.Buffer
+	outputJUnit(&buf, CheckResult{Results: CheckResults{
+		Mismatched: []ComparisonEntry{{Name: "rails", Version: "7.1", Detected: "7.0 <beta>", RuleID: ruleVersionMismatch}},
+	}})
+
+	var report junitTestSuites
+	if err := xml.Unmarshal(buf.Bytes(), &report); err != nil {
+		t.Fatalf("outputJUnit: invalid XML: %v\n%s", err, buf.String())
+	}
+	if report.Failures != 1 {
+		t.Errorf("outputJUnit: expected 1 failure, got %d", report.Failures)
+	}
+}
@@ -8,17 +8,23 @@ import (
 	"os"
 	"path/filepath"
 	"time"
+
+	"github.com/stacktodate/stacktodate-cli/cmd/lib/versions"
 )
 
 type ProductsCache struct {
-	Timestamp time.Time `json:"timestamp"`
-	Products  []Product `json:"products"`
+	Timestamp    time.Time `json:"timestamp"`
+	ETag         string    `json:"etag,omitempty"`         // Sent back as If-None-Match
+	LastModified string    `json:"lastModified,omitempty"` // Sent back as If-Modified-Since
+	SnapshotDate time.Time `json:"snapshotDate,omitzero"`  // When an imported snapshot was fetched from the API
+	Products     []Product `json:"products"`
 }
 
 type Product struct {
 	Key      string    `json:"key"`
 	Name     string    `json:"name"`
 	Releases []Release `json:"releases"`
+	Overlay  bool      `json:"-"` // Added by the catalog overlay
 }
 
 type Release struct {
@@ -28,11 +34,35 @@ type Release struct {
 	Extended     string `json:"extended,omitempty"`
 	EOL          string `json:"eol,omitempty"`
 	LTS          bool   `json:"lts"`
+	Overlay      bool   `json:"-"` // Added or changed by the catalog overlay
 }
 
 const cacheFileName = "products-cache.json"
 const cacheDirName = ".stacktodate"
-const cacheTTL = 24 * time.Hour
+
+// DefaultTTL is how long the cached catalog is used before it is revalidated
+const DefaultTTL = 24 * time.Hour
+
+// cacheTTL resolves how long the cached catalog is used before it is revalidated
+var cacheTTL = func() (time.Duration, error) { return DefaultTTL, nil }
+
+var httpClient = http.DefaultClient
+
+// SetTTL changes how long the cached catalog is used before it is revalidated
+func SetTTL(ttl time.Duration) {
+	cacheTTL = func() (time.Duration, error) { return ttl, nil }
+}
+
+// SetTTLFunc sets the function resolving the TTL when the cached catalog is used, so that
+// invalid TTL settings only fail commands reading the catalog
+func SetTTLFunc(ttl func() (time.Duration, error)) {
+	cacheTTL = ttl
+}
+
+// SetHTTPClient changes the client used to fetch the catalog
+func SetHTTPClient(client *http.Client) {
+	httpClient = client
+}
 
 // GetCachePath returns the full path to the cache file
 func GetCachePath() (string, error) {
@@ -45,7 +75,7 @@ func GetCachePath() (string, error) {
 	return filepath.Join(cacheDir, cacheFileName), nil
 }
 
-// IsCacheValid checks if cache exists and is less than 24 hours old
+// IsCacheValid checks if cache exists and is younger than the cache TTL
 func IsCacheValid() bool {
 	cachePath, err := GetCachePath()
 	if err != nil {
@@ -57,7 +87,11 @@ func IsCacheValid() bool {
 		return false
 	}
 
-	return time.Since(info.ModTime()) < cacheTTL
+	ttl, err := cacheTTL()
+	if err != nil {
+		return false
+	}
+	return time.Since(info.ModTime()) < ttl
 }
 
 // LoadCache loads cached products from disk
@@ -82,6 +116,11 @@ func LoadCache() (*ProductsCache, error) {
 
 // SaveCache saves products to cache file with timestamp
 func SaveCache(products []Product) error {
+	return saveCache(&ProductsCache{Products: products})
+}
+
+// saveCache writes the cache file, stamping it with the current time
+func saveCache(cache *ProductsCache) error {
 	cachePath, err := GetCachePath()
 	if err != nil {
 		return err
@@ -93,10 +132,7 @@ func SaveCache(products []Product) error {
 		return fmt.Errorf("failed to create cache directory: %w", err)
 	}
 
-	cache := ProductsCache{
-		Timestamp: time.Now(),
-		Products:  products,
-	}
+	cache.Timestamp = time.Now()
 
 	data, err := json.MarshalIndent(cache, "", "  ")
 	if err != nil {
@@ -110,41 +146,77 @@ func SaveCache(products []Product) error {
 	return nil
 }
 
-// FetchAndCache downloads products from stacktodate.club API and caches them
-func FetchAndCache() error {
+// FetchAndCache downloads products from stacktodate.club API and caches them.
+// It reports whether the catalog changed since it was last cached.
+func FetchAndCache() (bool, error) {
 	return FetchAndCacheWithURL(GetAPIURL())
 }
 
-// FetchAndCacheWithURL is the internal function that fetches with a specific URL
-func FetchAndCacheWithURL(apiURL string) error {
+// FetchAndCacheWithURL is the internal function that fetches with a specific URL.
+// When a cached catalog exists, the request is conditional and a 304 Not Modified
+// response only renews the cache timestamp.
+func FetchAndCacheWithURL(apiURL string) (bool, error) {
 	url := fmt.Sprintf("%s/api/v1/products", apiURL)
 
-	resp, err := http.Get(url)
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	cached, _ := LoadCache()
+	if cached != nil {
+		if cached.ETag != "" {
+			req.Header.Set("If-None-Match", cached.ETag)
+		}
+		if cached.LastModified != "" {
+			req.Header.Set("If-Modified-Since", cached.LastModified)
+		}
+	}
+
+	resp, err := httpClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("failed to fetch from API: %w", err)
+		return false, fmt.Errorf("failed to fetch from API: %w", err)
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
+		return false, saveCache(cached)
+	}
+
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(body))
+		return false, fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(body))
 	}
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return fmt.Errorf("failed to read response body: %w", err)
+		return false, fmt.Errorf("failed to read response body: %w", err)
 	}
 
 	var products []Product
 	if err := json.Unmarshal(body, &products); err != nil {
-		return fmt.Errorf("failed to parse API response: %w", err)
+		return false, fmt.Errorf("failed to parse API response: %w", err)
 	}
 
-	if err := SaveCache(products); err != nil {
-		return err
+	// Servers without validators send the full catalog every time, so compare it
+	changed := cached == nil || !sameProducts(cached.Products, products)
+
+	if err := saveCache(&ProductsCache{
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+		Products:     products,
+	}); err != nil {
+		return false, err
 	}
 
-	return nil
+	return changed, nil
+}
+
+// sameProducts reports whether two catalogs hold the same data
+func sameProducts(a, b []Product) bool {
+	dataA, errA := json.Marshal(a)
+	dataB, errB := json.Marshal(b)
+	return errA == nil && errB == nil && string(dataA) == string(dataB)
 }
 
 // GetAPIURL returns the API URL from environment or default
@@ -156,9 +228,37 @@ func GetAPIURL() string {
 	return apiURL
 }
 
-// GetProducts returns cached products, fetching if necessary
+// GetProducts returns cached products, fetching if necessary, merged with the catalog overlay
 // It handles cache expiration and auto-fetches if cache is stale
+// When STD_CATALOG_FILE is set, the products come from that snapshot without any network call
 func GetProducts() ([]Product, error) {
+	overlay, err := LoadOverlay()
+	if err != nil {
+		return nil, err
+	}
+
+	products, err := loadProducts()
+	if err != nil {
+		return nil, err
+	}
+
+	return ApplyOverlay(products, overlay), nil
+}
+
+// loadProducts returns the catalog from STD_CATALOG_FILE or the cache, fetching if necessary
+func loadProducts() ([]Product, error) {
+	if path := os.Getenv(CatalogFileEnv); path != "" {
+		snapshot, err := ReadSnapshot(path)
+		if err != nil {
+			return nil, err
+		}
+		return snapshot.Products, nil
+	}
+
+	if _, err := cacheTTL(); err != nil {
+		return nil, fmt.Errorf("invalid catalog TTL: %w", err)
+	}
+
 	// Check if cache is valid
 	if IsCacheValid() {
 		cache, err := LoadCache()
@@ -168,7 +268,7 @@ func GetProducts() ([]Product, error) {
 	}
 
 	// Cache is invalid or missing, fetch new data
-	if err := FetchAndCache(); err != nil {
+	if _, err := FetchAndCache(); err != nil {
 		// If fetch fails, try to return stale cache as fallback
 		cache, err2 := LoadCache()
 		if err2 == nil && cache != nil {
@@ -197,3 +297,61 @@ func GetProductByKey(key string, products []Product) *Product {
 	return nil
 }
 
+// FindRelease returns the release whose cycle matches version, trying the full
+// version, then major.minor, then major (e.g. 3.11.4 -> 3.11 -> 3)
+func (p *Product) FindRelease(version string) *Release {
+	if release := p.findCycle(version); release != nil {
+		return release
+	}
+
+	v, err := versions.Parse(version)
+	if err != nil {
+		return nil
+	}
+	for n := v.Len(); n > 0; n-- {
+		if release := p.findCycle(v.Truncate(n).String()); release != nil {
+			return release
+		}
+	}
+	return nil
+}
+
+func (p *Product) findCycle(cycle string) *Release {
+	for i := range p.Releases {
+		if p.Releases[i].ReleaseCycle == cycle {
+			return &p.Releases[i]
+		}
+	}
+	return nil
+}
+
+// IsEOL reports whether the release has reached end of life at the given time
+func (r Release) IsEOL(now time.Time) bool {
+	if date, ok := r.EOLDate(); ok {
+		return !now.Before(date)
+	}
+	return r.EOL == "true"
+}
+
+// EOLDate returns the end of life date, if the catalog has one
+func (r Release) EOLDate() (time.Time, bool) {
+	return parseDate(r.EOL)
+}
+
+// SupportDate returns the end of active support date, if the catalog has one
+func (r Release) SupportDate() (time.Time, bool) {
+	return parseDate(r.Support)
+}
+
+// ExtendedDate returns the end of extended support date, if the catalog has one
+func (r Release) ExtendedDate() (time.Time, bool) {
+	return parseDate(r.Extended)
+}
+
+func parseDate(value string) (time.Time, bool) {
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return date, true
+}
@@ -0,0 +1,89 @@
+package cache
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestFetchAndCacheConditional(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	catalog := `[{"key":"go","name":"Go","releases":[{"releaseCycle":"1.22","releaseDate":"2024-02-06","lts":false}]}]`
+	etag := `"v1"`
+	requests := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		w.Header().Set("Last-Modified", "Tue, 01 Oct 2026 00:00:00 GMT")
+		fmt.Fprint(w, catalog)
+	}))
+	defer server.Close()
+
+	changed, err := FetchAndCacheWithURL(server.URL)
+	if err != nil {
+		t.Fatalf("FetchAndCacheWithURL: unexpected error: %v", err)
+	}
+	if !changed {
+		t.Errorf("FetchAndCacheWithURL: expected first fetch to report a change")
+	}
+
+	cache, err := LoadCache()
+	if err != nil {
+		t.Fatalf("LoadCache: unexpected error: %v", err)
+	}
+	if cache.ETag != etag || cache.LastModified == "" || len(cache.Products) != 1 {
+		t.Errorf("LoadCache: expected validators and 1 product, got %+v", cache)
+	}
+	firstTimestamp := cache.Timestamp
+
+	time.Sleep(10 * time.Millisecond)
+	changed, err = FetchAndCacheWithURL(server.URL)
+	if err != nil {
+		t.Fatalf("FetchAndCacheWithURL: unexpected error: %v", err)
+	}
+	if changed {
+		t.Errorf("FetchAndCacheWithURL: expected 304 to report no change")
+	}
+
+	cache, _ = LoadCache()
+	if len(cache.Products) != 1 || !cache.Timestamp.After(firstTimestamp) {
+		t.Errorf("LoadCache: expected products kept and timestamp renewed, got %+v", cache)
+	}
+
+	// Servers without validators are compared by content
+	etag = ""
+	changed, err = FetchAndCacheWithURL(server.URL)
+	if err != nil || changed {
+		t.Errorf("FetchAndCacheWithURL: expected unchanged catalog without validators, got %v, %v", changed, err)
+	}
+
+	if requests != 3 {
+		t.Errorf("Expected 3 requests, got %d", requests)
+	}
+}
+
+func TestIsCacheValidTTL(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	defer SetTTL(DefaultTTL)
+
+	if err := SaveCache([]Product{{Key: "go"}}); err != nil {
+		t.Fatalf("SaveCache: unexpected error: %v", err)
+	}
+
+	if !IsCacheValid() {
+		t.Errorf("IsCacheValid: expected fresh cache to be valid")
+	}
+
+	SetTTL(0)
+	if IsCacheValid() {
+		t.Errorf("IsCacheValid: expected cache to expire with a zero TTL")
+	}
+}
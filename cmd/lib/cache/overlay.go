@@ -0,0 +1,164 @@
+package cache
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// OverlayFileEnv is the environment variable that points to a catalog overlay file,
+// replacing the default ~/.stacktodate/catalog-overlay.yaml
+const OverlayFileEnv = "STD_CATALOG_OVERLAY"
+
+const overlayFileName = "catalog-overlay.yaml"
+
+// Overlay adds products and overrides release fields of the catalog, e.g. for internal
+// libraries or runtimes with vendor extended support
+type Overlay struct {
+	Products []OverlayProduct `yaml:"products"`
+}
+
+// OverlayProduct adds a product, or releases of an existing product, to the catalog
+type OverlayProduct struct {
+	Key      string           `yaml:"key"`
+	Name     string           `yaml:"name,omitempty"`
+	Releases []OverlayRelease `yaml:"releases"`
+}
+
+// OverlayRelease adds a release cycle or overrides the fields it sets of an existing one
+type OverlayRelease struct {
+	ReleaseCycle string `yaml:"releaseCycle"`
+	ReleaseDate  string `yaml:"releaseDate,omitempty"`
+	Support      string `yaml:"support,omitempty"`
+	Extended     string `yaml:"extended,omitempty"`
+	EOL          string `yaml:"eol,omitempty"`
+	LTS          *bool  `yaml:"lts,omitempty"`
+}
+
+// GetOverlayPath returns the path of the catalog overlay file
+func GetOverlayPath() (string, error) {
+	if path := os.Getenv(OverlayFileEnv); path != "" {
+		return path, nil
+	}
+
+	cachePath, err := GetCachePath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(cachePath), overlayFileName), nil
+}
+
+// LoadOverlay reads and validates the catalog overlay file. It returns nil when the
+// default overlay file does not exist.
+func LoadOverlay() (*Overlay, error) {
+	path, err := GetOverlayPath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) && os.Getenv(OverlayFileEnv) == "" {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read catalog overlay: %w", err)
+	}
+
+	var overlay Overlay
+	if err := yaml.Unmarshal(data, &overlay); err != nil {
+		return nil, fmt.Errorf("failed to parse catalog overlay %s: %w", path, err)
+	}
+	if err := validateOverlay(&overlay); err != nil {
+		return nil, fmt.Errorf("invalid catalog overlay %s: %w", path, err)
+	}
+
+	return &overlay, nil
+}
+
+// validateOverlay checks that every product has a key and every release a cycle and
+// well-formed dates
+func validateOverlay(overlay *Overlay) error {
+	for i, product := range overlay.Products {
+		if product.Key == "" {
+			return fmt.Errorf("product %d has no key", i+1)
+		}
+
+		for _, release := range product.Releases {
+			if release.ReleaseCycle == "" {
+				return fmt.Errorf("product %q has a release without a releaseCycle", product.Key)
+			}
+			dates := []struct{ field, value string }{
+				{"support", release.Support},
+				{"eol", release.EOL},
+				{"extended", release.Extended},
+			}
+			for _, date := range dates {
+				if !isDateOrFlag(date.value) {
+					return fmt.Errorf("product %q release %s has an invalid %s %q", product.Key, release.ReleaseCycle, date.field, date.value)
+				}
+			}
+			if _, ok := parseDate(release.ReleaseDate); release.ReleaseDate != "" && !ok {
+				return fmt.Errorf("product %q release %s has an invalid releaseDate %q", product.Key, release.ReleaseCycle, release.ReleaseDate)
+			}
+		}
+	}
+
+	return nil
+}
+
+// ApplyOverlay returns the products merged with the overlay. Products and releases the
+// overlay adds or changes are marked with Overlay; the given products are not modified.
+func ApplyOverlay(products []Product, overlay *Overlay) []Product {
+	merged := make([]Product, len(products))
+	for i, product := range products {
+		merged[i] = product
+		merged[i].Releases = append([]Release(nil), product.Releases...)
+	}
+	if overlay == nil {
+		return merged
+	}
+
+	for _, overlayProduct := range overlay.Products {
+		product := GetProductByKey(overlayProduct.Key, merged)
+		if product == nil {
+			merged = append(merged, Product{Key: overlayProduct.Key, Name: overlayProduct.Key, Overlay: true})
+			product = &merged[len(merged)-1]
+		}
+		if overlayProduct.Name != "" {
+			product.Name = overlayProduct.Name
+		}
+
+		for _, overlayRelease := range overlayProduct.Releases {
+			release := product.findCycle(overlayRelease.ReleaseCycle)
+			if release == nil {
+				product.Releases = append(product.Releases, Release{ReleaseCycle: overlayRelease.ReleaseCycle})
+				release = &product.Releases[len(product.Releases)-1]
+			}
+			overlayRelease.apply(release)
+		}
+	}
+
+	return merged
+}
+
+// apply sets the fields the overlay release sets on release
+func (o OverlayRelease) apply(release *Release) {
+	if o.ReleaseDate != "" {
+		release.ReleaseDate = o.ReleaseDate
+	}
+	if o.Support != "" {
+		release.Support = o.Support
+	}
+	if o.Extended != "" {
+		release.Extended = o.Extended
+	}
+	if o.EOL != "" {
+		release.EOL = o.EOL
+	}
+	if o.LTS != nil {
+		release.LTS = *o.LTS
+	}
+	release.Overlay = true
+}
@@ -0,0 +1,91 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestApplyOverlay(t *testing.T) {
+	products := []Product{
+		{Key: "ruby", Name: "Ruby", Releases: []Release{
+			{ReleaseCycle: "3.3", EOL: "2027-03-31"},
+			{ReleaseCycle: "2.7", ReleaseDate: "2019-12-25", EOL: "2023-03-31"},
+		}},
+	}
+	lts := true
+	overlay := &Overlay{Products: []OverlayProduct{
+		{Key: "ruby", Releases: []OverlayRelease{{ReleaseCycle: "2.7", EOL: "2027-03-31", LTS: &lts}}},
+		{Key: "internal-auth", Name: "Internal Auth", Releases: []OverlayRelease{{ReleaseCycle: "2", EOL: "2026-12-31"}}},
+	}}
+
+	merged := ApplyOverlay(products, overlay)
+
+	ruby := GetProductByKey("ruby", merged)
+	if ruby == nil || ruby.Overlay {
+		t.Fatalf("ApplyOverlay: expected the catalog's ruby product, got %+v", ruby)
+	}
+	if release := ruby.FindRelease("3.3"); release.Overlay {
+		t.Errorf("ApplyOverlay: expected ruby 3.3 to be untouched, got %+v", release)
+	}
+	release := ruby.FindRelease("2.7")
+	if release.EOL != "2027-03-31" || release.ReleaseDate != "2019-12-25" || !release.LTS || !release.Overlay {
+		t.Errorf("ApplyOverlay: expected ruby 2.7 with the overlay's EOL and LTS, got %+v", release)
+	}
+
+	internal := GetProductByKey("internal-auth", merged)
+	if internal == nil || !internal.Overlay || internal.Name != "Internal Auth" || len(internal.Releases) != 1 || !internal.Releases[0].Overlay {
+		t.Errorf("ApplyOverlay: expected the internal-auth product from the overlay, got %+v", internal)
+	}
+
+	// The fetched catalog is left as it was
+	if products[0].Releases[1].EOL != "2023-03-31" || products[0].Releases[1].Overlay {
+		t.Errorf("ApplyOverlay: modified the given products: %+v", products[0].Releases[1])
+	}
+}
+
+func TestLoadOverlay(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(OverlayFileEnv, "")
+
+	overlay, err := LoadOverlay()
+	if overlay != nil || err != nil {
+		t.Errorf("LoadOverlay() = %+v, %v, want nil without an overlay file", overlay, err)
+	}
+
+	path := filepath.Join(t.TempDir(), "overlay.yaml")
+	content := `products:
+  - key: ruby
+    releases:
+      - releaseCycle: "2.7"
+        eol: 2027-03-31
+        lts: true
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(OverlayFileEnv, path)
+
+	overlay, err = LoadOverlay()
+	if err != nil {
+		t.Fatalf("LoadOverlay: unexpected error: %v", err)
+	}
+	release := overlay.Products[0].Releases[0]
+	if release.EOL != "2027-03-31" || release.LTS == nil || !*release.LTS {
+		t.Errorf("LoadOverlay: expected ruby 2.7 with EOL 2027-03-31 and LTS, got %+v", release)
+	}
+
+	if err := os.WriteFile(path, []byte("products:\n  - key: ruby\n    releases:\n      - releaseCycle: \"2.7\"\n        eol: soon\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadOverlay(); err == nil || !strings.Contains(err.Error(), "invalid eol") {
+		t.Errorf("LoadOverlay: expected an invalid eol error, got %v", err)
+	}
+
+	// An overlay named explicitly must exist
+	t.Setenv(OverlayFileEnv, filepath.Join(t.TempDir(), "missing.yaml"))
+	if _, err := LoadOverlay(); err == nil {
+		t.Errorf("LoadOverlay: expected an error for a missing overlay file")
+	}
+}
@@ -0,0 +1,146 @@
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// CatalogFileEnv is the environment variable that pins the catalog to a snapshot file.
+// When it is set, the catalog is read from that file and never fetched.
+const CatalogFileEnv = "STD_CATALOG_FILE"
+
+// ExportSnapshot writes the cached catalog to path as a snapshot that can be imported
+// on another machine, dated when the catalog was fetched
+func ExportSnapshot(path string) (*ProductsCache, error) {
+	cache, err := LoadCache()
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := &ProductsCache{
+		Timestamp:    cache.Timestamp,
+		SnapshotDate: cache.snapshotDate(),
+		Products:     cache.Products,
+	}
+	if err := validateSnapshot(snapshot); err != nil {
+		return nil, fmt.Errorf("cached catalog is not valid: %w", err)
+	}
+
+	data, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal catalog: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return nil, fmt.Errorf("failed to write snapshot: %w", err)
+	}
+
+	return snapshot, nil
+}
+
+// ImportSnapshot validates the snapshot at path and replaces the cached catalog with it.
+// The snapshot date is kept so that reports can tell how old the catalog is.
+func ImportSnapshot(path string) (*ProductsCache, error) {
+	snapshot, err := ReadSnapshot(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := saveCache(snapshot); err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// ReadSnapshot reads and validates a catalog snapshot written by ExportSnapshot
+func ReadSnapshot(path string) (*ProductsCache, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot: %w", err)
+	}
+
+	var snapshot ProductsCache
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, fmt.Errorf("failed to parse snapshot %s: %w", path, err)
+	}
+	if err := validateSnapshot(&snapshot); err != nil {
+		return nil, fmt.Errorf("invalid snapshot %s: %w", path, err)
+	}
+
+	snapshot.SnapshotDate = snapshot.snapshotDate()
+	return &snapshot, nil
+}
+
+// SnapshotDate returns the date of the catalog in use when it comes from a snapshot,
+// either pinned with STD_CATALOG_FILE or imported into the cache
+func SnapshotDate() (time.Time, bool) {
+	if path := os.Getenv(CatalogFileEnv); path != "" {
+		snapshot, err := ReadSnapshot(path)
+		if err != nil {
+			return time.Time{}, false
+		}
+		return snapshot.SnapshotDate, true
+	}
+
+	cache, err := LoadCache()
+	if err != nil || cache.SnapshotDate.IsZero() {
+		return time.Time{}, false
+	}
+	return cache.SnapshotDate, true
+}
+
+// snapshotDate returns when the catalog data was fetched from the API
+func (c *ProductsCache) snapshotDate() time.Time {
+	if !c.SnapshotDate.IsZero() {
+		return c.SnapshotDate
+	}
+	return c.Timestamp
+}
+
+// validateSnapshot checks that a snapshot is dated and that every product has a key and
+// releases with a cycle and well-formed dates
+func validateSnapshot(snapshot *ProductsCache) error {
+	if snapshot.snapshotDate().IsZero() {
+		return fmt.Errorf("missing snapshot date")
+	}
+	if len(snapshot.Products) == 0 {
+		return fmt.Errorf("no products")
+	}
+
+	keys := make(map[string]bool, len(snapshot.Products))
+	for i, product := range snapshot.Products {
+		if product.Key == "" {
+			return fmt.Errorf("product %d has no key", i+1)
+		}
+		if keys[product.Key] {
+			return fmt.Errorf("duplicate product %q", product.Key)
+		}
+		keys[product.Key] = true
+
+		for _, release := range product.Releases {
+			if release.ReleaseCycle == "" {
+				return fmt.Errorf("product %q has a release without a releaseCycle", product.Key)
+			}
+			if !isDateOrFlag(release.EOL) {
+				return fmt.Errorf("product %q release %s has an invalid eol %q", product.Key, release.ReleaseCycle, release.EOL)
+			}
+			if release.ReleaseDate != "" {
+				if _, err := time.Parse("2006-01-02", release.ReleaseDate); err != nil {
+					return fmt.Errorf("product %q release %s has an invalid releaseDate %q", product.Key, release.ReleaseCycle, release.ReleaseDate)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// isDateOrFlag reports whether an EOL value is empty, a boolean flag or a date
+func isDateOrFlag(value string) bool {
+	if value == "" || value == "true" || value == "false" {
+		return true
+	}
+	_, err := time.Parse("2006-01-02", value)
+	return err == nil
+}
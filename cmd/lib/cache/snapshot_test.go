@@ -0,0 +1,109 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExportImportSnapshot(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(CatalogFileEnv, "")
+
+	products := []Product{{Key: "go", Name: "Go", Releases: []Release{{ReleaseCycle: "1.22", ReleaseDate: "2024-02-06", EOL: "2025-02-11"}}}}
+	if err := SaveCache(products); err != nil {
+		t.Fatalf("SaveCache: unexpected error: %v", err)
+	}
+	cached, _ := LoadCache()
+
+	path := filepath.Join(t.TempDir(), "catalog.json")
+	if _, err := ExportSnapshot(path); err != nil {
+		t.Fatalf("ExportSnapshot: unexpected error: %v", err)
+	}
+
+	// Importing on another machine keeps the date the catalog was fetched
+	t.Setenv("HOME", t.TempDir())
+	time.Sleep(10 * time.Millisecond)
+	snapshot, err := ImportSnapshot(path)
+	if err != nil {
+		t.Fatalf("ImportSnapshot: unexpected error: %v", err)
+	}
+	if !snapshot.SnapshotDate.Equal(cached.Timestamp) {
+		t.Errorf("ImportSnapshot: snapshot date = %v, want %v", snapshot.SnapshotDate, cached.Timestamp)
+	}
+
+	date, ok := SnapshotDate()
+	if !ok || !date.Equal(cached.Timestamp) {
+		t.Errorf("SnapshotDate() = %v, %v, want %v, true", date, ok, cached.Timestamp)
+	}
+
+	imported, err := LoadCache()
+	if err != nil || len(imported.Products) != 1 || !imported.Timestamp.After(cached.Timestamp) {
+		t.Errorf("LoadCache: expected imported products with a renewed timestamp, got %+v, %v", imported, err)
+	}
+}
+
+func TestGetProductsFromCatalogFile(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("STD_API_URL", "http://127.0.0.1:1")
+
+	path := filepath.Join(t.TempDir(), "catalog.json")
+	content := `{"timestamp":"2026-01-15T00:00:00Z","products":[{"key":"nodejs","name":"Node.js","releases":[{"releaseCycle":"20","releaseDate":"2023-04-18","eol":"2026-04-30","lts":true}]}]}`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(CatalogFileEnv, path)
+
+	products, err := GetProducts()
+	if err != nil {
+		t.Fatalf("GetProducts: unexpected error: %v", err)
+	}
+	if len(products) != 1 || products[0].Key != "nodejs" {
+		t.Errorf("GetProducts: expected the snapshot's products, got %+v", products)
+	}
+
+	date, ok := SnapshotDate()
+	if !ok || date.Format("2006-01-02") != "2026-01-15" {
+		t.Errorf("SnapshotDate() = %v, %v, want 2026-01-15, true", date, ok)
+	}
+
+	// Nothing is written to the cache
+	if _, err := LoadCache(); err == nil {
+		t.Errorf("LoadCache: expected no cache file")
+	}
+}
+
+func TestReadSnapshotValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{"not json", `[`, "failed to parse"},
+		{"undated", `{"products":[{"key":"go","releases":[]}]}`, "missing snapshot date"},
+		{"empty", `{"timestamp":"2026-01-15T00:00:00Z","products":[]}`, "no products"},
+		{"no key", `{"timestamp":"2026-01-15T00:00:00Z","products":[{"name":"Go"}]}`, "has no key"},
+		{"duplicate", `{"timestamp":"2026-01-15T00:00:00Z","products":[{"key":"go"},{"key":"go"}]}`, "duplicate product"},
+		{"no cycle", `{"timestamp":"2026-01-15T00:00:00Z","products":[{"key":"go","releases":[{"eol":"true"}]}]}`, "without a releaseCycle"},
+		{"bad eol", `{"timestamp":"2026-01-15T00:00:00Z","products":[{"key":"go","releases":[{"releaseCycle":"1.22","eol":"soon"}]}]}`, "invalid eol"},
+		{"valid", `{"snapshotDate":"2026-01-15T00:00:00Z","products":[{"key":"go","releases":[{"releaseCycle":"1.22","eol":"false"}]}]}`, ""},
+	}
+
+	for _, tt := range tests {
+		path := filepath.Join(t.TempDir(), "catalog.json")
+		if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		_, err := ReadSnapshot(path)
+		if tt.wantErr == "" {
+			if err != nil {
+				t.Errorf("ReadSnapshot(%s): unexpected error: %v", tt.name, err)
+			}
+		} else if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("ReadSnapshot(%s) error = %v, want %q", tt.name, err, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package detectors
+
+import "io/fs"
+
+func init() {
+	Register(FuncDetector{
+		ProductKey:    "bundler",
+		DisplayName:   "Bundler",
+		DetectFunc:    DetectBundler,
+		ManifestNames: []string{"Gemfile.lock"},
+	})
+}
+
+// DetectBundler checks the BUNDLED WITH section of Gemfile.lock
+func DetectBundler(fsys fs.FS) []Candidate {
+	var candidates []Candidate
+
+	if lock, err := readGemfileLock(fsys); err == nil && lock.BundledWith != "" {
+		candidates = append(candidates, Candidate{
+			Value:  lock.BundledWith,
+			Source: "Gemfile.lock",
+			Line:   lock.BundledLine,
+		})
+	}
+
+	return candidates
+}
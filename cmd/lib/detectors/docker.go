@@ -1,21 +1,23 @@
 package detectors
 
 import (
-	"os"
-	"path/filepath"
+	"io/fs"
 	"regexp"
 	"strings"
 )
 
+// dockerManifests lists the files read by DetectDocker
+var dockerManifests = []string{"*Dockerfile*", "docker-compose.yml"}
+
 // DetectDocker extracts Docker base images from Dockerfiles and docker-compose.yml
-func DetectDocker() []Candidate {
+func DetectDocker(fsys fs.FS) []Candidate {
 	var candidates []Candidate
 
 	// Find all Dockerfiles
-	files, err := filepath.Glob("*Dockerfile*")
+	files, err := fs.Glob(fsys, "*Dockerfile*")
 	if err == nil && len(files) > 0 {
 		for _, file := range files {
-			data, err := os.ReadFile(file)
+			data, err := fs.ReadFile(fsys, file)
 			if err != nil {
 				continue
 			}
@@ -24,33 +26,31 @@ func DetectDocker() []Candidate {
 
 			// Extract FROM statements
 			re := regexp.MustCompile(`(?m)^FROM\s+(.+)`)
-			matches := re.FindAllStringSubmatch(content, -1)
+			matches := re.FindAllStringSubmatchIndex(content, -1)
 			for _, match := range matches {
-				if len(match) > 1 {
-					image := strings.Split(match[1], " ")[0]
-					candidates = append(candidates, Candidate{
-						Value:  image,
-						Source: file,
-					})
-				}
+				image := strings.Split(content[match[2]:match[3]], " ")[0]
+				candidates = append(candidates, Candidate{
+					Value:  image,
+					Source: file,
+					Line:   lineAt(data, match[0]),
+				})
 			}
 		}
 	}
 
 	// Check docker-compose.yml
-	if data, err := os.ReadFile("docker-compose.yml"); err == nil {
+	if data, err := fs.ReadFile(fsys, "docker-compose.yml"); err == nil {
 		content := string(data)
 
 		// Extract image references
 		re := regexp.MustCompile(`(?m)^\s*image:\s*(.+)`)
-		matches := re.FindAllStringSubmatch(content, -1)
+		matches := re.FindAllStringSubmatchIndex(content, -1)
 		for _, match := range matches {
-			if len(match) > 1 {
-				candidates = append(candidates, Candidate{
-					Value:  strings.TrimSpace(match[1]),
-					Source: "docker-compose.yml",
-				})
-			}
+			candidates = append(candidates, Candidate{
+				Value:  strings.TrimSpace(content[match[2]:match[3]]),
+				Source: "docker-compose.yml",
+				Line:   lineAt(data, match[2]),
+			})
 		}
 	}
 
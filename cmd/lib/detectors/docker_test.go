@@ -2,6 +2,7 @@ package detectors
 
 import (
 	"os"
+	"path/filepath"
 	"testing"
 )
 
@@ -87,29 +88,22 @@ services:
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tmpDir := t.TempDir()
-			oldWd, err := os.Getwd()
-			if err != nil {
-				t.Fatalf("Failed to get working directory: %v", err)
-			}
-			defer os.Chdir(oldWd)
-
-			os.Chdir(tmpDir)
 
 			if tt.dockerfileContent != "" {
-				err := os.WriteFile("Dockerfile", []byte(tt.dockerfileContent), 0644)
+				err := os.WriteFile(filepath.Join(tmpDir, "Dockerfile"), []byte(tt.dockerfileContent), 0644)
 				if err != nil {
 					t.Fatalf("Failed to write Dockerfile: %v", err)
 				}
 			}
 
 			if tt.composeContent != "" {
-				err := os.WriteFile("docker-compose.yml", []byte(tt.composeContent), 0644)
+				err := os.WriteFile(filepath.Join(tmpDir, "docker-compose.yml"), []byte(tt.composeContent), 0644)
 				if err != nil {
 					t.Fatalf("Failed to write docker-compose.yml: %v", err)
 				}
 			}
 
-			candidates := DetectDocker()
+			candidates := DetectDocker(os.DirFS(tmpDir))
 
 			if tt.expectFound {
 				if len(candidates) != len(tt.expectValues) {
@@ -137,15 +131,8 @@ services:
 
 func TestDetectDockerNoFiles(t *testing.T) {
 	tmpDir := t.TempDir()
-	oldWd, err := os.Getwd()
-	if err != nil {
-		t.Fatalf("Failed to get working directory: %v", err)
-	}
-	defer os.Chdir(oldWd)
-
-	os.Chdir(tmpDir)
 
-	candidates := DetectDocker()
+	candidates := DetectDocker(os.DirFS(tmpDir))
 	if len(candidates) != 0 {
 		t.Errorf("Expected no candidates when Docker files don't exist, got %v", candidates)
 	}
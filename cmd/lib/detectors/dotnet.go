@@ -0,0 +1,171 @@
+package detectors
+
+import (
+	"encoding/json"
+	"io/fs"
+	"path"
+	"regexp"
+	"strings"
+)
+
+func init() {
+	Register(FuncDetector{
+		ProductKey:    "dotnet",
+		DisplayName:   ".NET",
+		DetectFunc:    DetectDotnet,
+		ManifestNames: []string{"global.json", "*.csproj", "*.fsproj", "*.vbproj"},
+		ImageNames: []string{
+			"mcr.microsoft.com/dotnet/sdk",
+			"mcr.microsoft.com/dotnet/aspnet",
+			"mcr.microsoft.com/dotnet/runtime",
+			"mcr.microsoft.com/dotnet/runtime-deps",
+			"mcr.microsoft.com/dotnet/core/sdk",
+			"mcr.microsoft.com/dotnet/core/aspnet",
+			"mcr.microsoft.com/dotnet/core/runtime",
+		},
+	})
+}
+
+// dotnetBuildProps is the MSBuild file whose properties are inherited by projects below it
+const dotnetBuildProps = "Directory.Build.props"
+
+// dotnetSkipDirs lists directories that never contain source project files
+var dotnetSkipDirs = map[string]bool{
+	".git":         true,
+	"bin":          true,
+	"obj":          true,
+	"node_modules": true,
+	"vendor":       true,
+}
+
+// DetectDotnet checks global.json and MSBuild project files for .NET version
+func DetectDotnet(fsys fs.FS) []Candidate {
+	var candidates []Candidate
+
+	// Check global.json SDK version
+	if data, err := fs.ReadFile(fsys, "global.json"); err == nil {
+		var globalJSON struct {
+			SDK struct {
+				Version string `json:"version"`
+			} `json:"sdk"`
+		}
+		if err := json.Unmarshal(data, &globalJSON); err == nil && globalJSON.SDK.Version != "" {
+			candidates = append(candidates, Candidate{
+				Value:  globalJSON.SDK.Version,
+				Source: "global.json",
+				Line:   lineOf(data, `"version"`, globalJSON.SDK.Version),
+			})
+		}
+	}
+
+	// Check TargetFramework(s) of every project, falling back to Directory.Build.props
+	seen := make(map[Candidate]bool)
+	for _, project := range findDotnetProjects(fsys) {
+		source, file, name := project, fsys, project
+		versions := readTargetFrameworks(fsys, project)
+
+		if len(versions) == 0 {
+			var ok bool
+			source, file, name, ok = findBuildProps(fsys, path.Dir(project))
+			if !ok {
+				continue
+			}
+			versions = readTargetFrameworks(file, name)
+		}
+
+		for _, version := range versions {
+			candidate := Candidate{Value: version, Source: source, Line: fileLineOf(file, name, version)}
+			if !seen[candidate] {
+				seen[candidate] = true
+				candidates = append(candidates, candidate)
+			}
+		}
+	}
+
+	return candidates
+}
+
+// findDotnetProjects returns the paths of all C#, F# and VB project files in fsys
+func findDotnetProjects(fsys fs.FS) []string {
+	var projects []string
+
+	fs.WalkDir(fsys, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() {
+			if name != "." && dotnetSkipDirs[d.Name()] {
+				return fs.SkipDir
+			}
+			return nil
+		}
+
+		switch path.Ext(name) {
+		case ".csproj", ".fsproj", ".vbproj":
+			projects = append(projects, name)
+		}
+		return nil
+	})
+
+	return projects
+}
+
+// findBuildProps returns the nearest Directory.Build.props defining a target framework,
+// searching from dir up to the root of fsys and on through the directories above a
+// sub-project. It returns the file's path relative to fsys, and the file system and
+// name to read it from.
+func findBuildProps(fsys fs.FS, dir string) (source string, file fs.FS, name string, ok bool) {
+	up := "."
+	for {
+		for {
+			props := path.Join(dir, dotnetBuildProps)
+			if len(readTargetFrameworks(fsys, props)) > 0 {
+				return path.Join(up, props), fsys, props, true
+			}
+			if dir == "." {
+				break
+			}
+			dir = path.Dir(dir)
+		}
+
+		parent, isSub := fsys.(parentFS)
+		if !isSub {
+			return "", nil, "", false
+		}
+		var root string
+		fsys, root = parent.Parent()
+		if root == "." {
+			return "", nil, "", false
+		}
+
+		// Continue from the directory above the root in the parent file system
+		for range strings.Split(root, "/") {
+			up = path.Join(up, "..")
+		}
+		dir = path.Dir(root)
+	}
+}
+
+// readTargetFrameworks extracts .NET versions from TargetFramework/TargetFrameworks in an MSBuild file
+// Examples: net8.0 -> 8.0, netcoreapp3.1 -> 3.1, net6.0;net8.0 -> [6.0 8.0]
+func readTargetFrameworks(fsys fs.FS, file string) []string {
+	data, err := fs.ReadFile(fsys, file)
+	if err != nil {
+		return nil
+	}
+
+	re := regexp.MustCompile(`<TargetFrameworks?>\s*([^<]+?)\s*</TargetFrameworks?>`)
+	frameworkRe := regexp.MustCompile(`^net(?:coreapp)?(\d+\.\d+)`)
+
+	var versions []string
+	for _, match := range re.FindAllStringSubmatch(string(data), -1) {
+		for _, framework := range strings.Split(match[1], ";") {
+			// .NET Framework (net48) and .NET Standard monikers are not .NET releases
+			if matches := frameworkRe.FindStringSubmatch(strings.TrimSpace(framework)); len(matches) > 1 {
+				versions = append(versions, matches[1])
+			}
+		}
+	}
+
+	return versions
+}
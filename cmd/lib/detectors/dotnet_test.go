@@ -0,0 +1,99 @@
+package detectors
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDetectDotnet(t *testing.T) {
+	tests := []struct {
+		name          string
+		files         map[string]string
+		expectValues  []string
+		expectSources []string
+	}{
+		{
+			name:          "sdk version from global.json",
+			files:         map[string]string{"global.json": `{"sdk": {"version": "8.0.100", "rollForward": "latestFeature"}}`},
+			expectValues:  []string{"8.0.100"},
+			expectSources: []string{"global.json"},
+		},
+		{
+			name: "target framework from csproj",
+			files: map[string]string{"Api.csproj": `<Project Sdk="Microsoft.NET.Sdk.Web">
+  <PropertyGroup>
+    <TargetFramework>net8.0</TargetFramework>
+  </PropertyGroup>
+</Project>`},
+			expectValues:  []string{"8.0"},
+			expectSources: []string{"Api.csproj"},
+		},
+		{
+			name: "multiple target frameworks",
+			files: map[string]string{"Lib.csproj": `<Project Sdk="Microsoft.NET.Sdk">
+  <PropertyGroup>
+    <TargetFrameworks>netstandard2.0;net6.0;net8.0-windows</TargetFrameworks>
+  </PropertyGroup>
+</Project>`},
+			expectValues:  []string{"6.0", "8.0"},
+			expectSources: []string{"Lib.csproj", "Lib.csproj"},
+		},
+		{
+			name: "target framework inherited from Directory.Build.props",
+			files: map[string]string{
+				"Directory.Build.props": "<Project><PropertyGroup><TargetFramework>net6.0</TargetFramework></PropertyGroup></Project>",
+				"A.csproj":              `<Project Sdk="Microsoft.NET.Sdk"></Project>`,
+				"B.fsproj":              `<Project Sdk="Microsoft.NET.Sdk"></Project>`,
+				"C.csproj":              `<Project Sdk="Microsoft.NET.Sdk"><PropertyGroup><TargetFramework>netcoreapp3.1</TargetFramework></PropertyGroup></Project>`,
+			},
+			expectValues:  []string{"6.0", "3.1"},
+			expectSources: []string{"Directory.Build.props", "C.csproj"},
+		},
+		{
+			name: "projects in subdirectories inherit the nearest Directory.Build.props",
+			files: map[string]string{
+				"src/Lib/Lib.csproj":            "<Project></Project>",
+				"src/Api/Api.csproj":            "<Project></Project>",
+				"src/Api/Directory.Build.props": "<Project><PropertyGroup><TargetFramework>net8.0</TargetFramework></PropertyGroup></Project>",
+				"vendor/X/X.csproj":             "<Project><PropertyGroup><TargetFramework>net5.0</TargetFramework></PropertyGroup></Project>",
+				"Directory.Build.props":         "<Project><PropertyGroup><TargetFramework>net6.0</TargetFramework></PropertyGroup></Project>",
+			},
+			expectValues:  []string{"8.0", "6.0"},
+			expectSources: []string{"src/Api/Directory.Build.props", "Directory.Build.props"},
+		},
+		{
+			name:  ".NET Framework project",
+			files: map[string]string{"Legacy.csproj": "<Project><PropertyGroup><TargetFramework>net48</TargetFramework></PropertyGroup></Project>"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+
+			for file, content := range tt.files {
+				if err := os.MkdirAll(filepath.Join(tmpDir, filepath.Dir(file)), 0755); err != nil {
+					t.Fatalf("Failed to create directory for %s: %v", file, err)
+				}
+				if err := os.WriteFile(filepath.Join(tmpDir, file), []byte(content), 0644); err != nil {
+					t.Fatalf("Failed to write %s: %v", file, err)
+				}
+			}
+
+			candidates := DetectDotnet(os.DirFS(tmpDir))
+
+			if len(candidates) != len(tt.expectValues) {
+				t.Fatalf("Expected %d candidates, got %d: %v", len(tt.expectValues), len(candidates), candidates)
+			}
+			for i, expected := range tt.expectValues {
+				if candidates[i].Value != expected {
+					t.Errorf("Expected value %q at index %d, got %q", expected, i, candidates[i].Value)
+				}
+				if candidates[i].Source != tt.expectSources[i] {
+					t.Errorf("Expected source %q at index %d, got %q", tt.expectSources[i], i, candidates[i].Source)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package detectors
+
+import (
+	"bufio"
+	"io/fs"
+	"regexp"
+	"strings"
+)
+
+// gemfileLock holds the parts of Gemfile.lock used for detection
+type gemfileLock struct {
+	Specs       map[string]string // Resolved gem versions keyed by gem name
+	SpecLines   map[string]int    // Line of each resolved gem
+	RubyVersion string            // From the RUBY VERSION section
+	RubyLine    int               // Line of the ruby version
+	BundledWith string            // From the BUNDLED WITH section
+	BundledLine int               // Line of the bundler version
+}
+
+// readGemfileLock parses Gemfile.lock at the root of fsys
+func readGemfileLock(fsys fs.FS) (*gemfileLock, error) {
+	data, err := fs.ReadFile(fsys, "Gemfile.lock")
+	if err != nil {
+		return nil, err
+	}
+
+	lock := &gemfileLock{Specs: make(map[string]string), SpecLines: make(map[string]int)}
+
+	// Specs are indented by exactly four spaces, their dependencies by six
+	specRe := regexp.MustCompile(`^    ([^\s(]+) \(([^)]+)\)$`)
+
+	section := ""
+	lineNumber := 0
+	scanner := bufio.NewScanner(strings.NewReader(string(data)))
+	for scanner.Scan() {
+		line := scanner.Text()
+		lineNumber++
+		if line == "" {
+			continue
+		}
+
+		// Unindented lines start a new section (GEM, PLATFORMS, RUBY VERSION...)
+		if !strings.HasPrefix(line, " ") {
+			section = strings.TrimSpace(line)
+			continue
+		}
+
+		switch section {
+		case "GEM", "GIT", "PATH":
+			if matches := specRe.FindStringSubmatch(line); len(matches) > 2 {
+				if _, exists := lock.Specs[matches[1]]; !exists {
+					lock.Specs[matches[1]] = matches[2]
+					lock.SpecLines[matches[1]] = lineNumber
+				}
+			}
+		case "RUBY VERSION":
+			// e.g. "   ruby 3.2.2p53"
+			if fields := strings.Fields(line); len(fields) >= 2 && fields[0] == "ruby" {
+				lock.RubyVersion = strings.SplitN(fields[1], "p", 2)[0]
+				lock.RubyLine = lineNumber
+			}
+		case "BUNDLED WITH":
+			lock.BundledWith = strings.TrimSpace(line)
+			lock.BundledLine = lineNumber
+		}
+	}
+
+	return lock, nil
+}
@@ -0,0 +1,141 @@
+package detectors
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const sampleGemfileLock = `GIT
+  remote: https://github.com/example/internal_gem.git
+  revision: 1234567
+  specs:
+    internal_gem (0.1.0)
+
+GEM
+  remote: https://rubygems.org/
+  specs:
+    actionpack (7.1.3)
+      rack (>= 2.2.4)
+    nokogiri (1.16.2-x86_64-linux)
+      racc (~> 1.4)
+    rails (7.1.3)
+      actionpack (= 7.1.3)
+      railties (= 7.1.3)
+    railties (7.1.3)
+
+PLATFORMS
+  x86_64-linux
+
+DEPENDENCIES
+  rails (~> 7.1.0)
+
+RUBY VERSION
+   ruby 3.2.2p53
+
+BUNDLED WITH
+   2.4.10
+`
+
+func TestReadGemfileLock(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	if err := os.WriteFile(filepath.Join(tmpDir, "Gemfile.lock"), []byte(sampleGemfileLock), 0644); err != nil {
+		t.Fatalf("Failed to write Gemfile.lock: %v", err)
+	}
+
+	lock, err := readGemfileLock(os.DirFS(tmpDir))
+	if err != nil {
+		t.Fatalf("readGemfileLock(os.DirFS(tmpDir)) failed: %v", err)
+	}
+
+	specs := map[string]string{
+		"internal_gem": "0.1.0",
+		"actionpack":   "7.1.3",
+		"nokogiri":     "1.16.2-x86_64-linux",
+		"rails":        "7.1.3",
+		"railties":     "7.1.3",
+	}
+	for gem, expected := range specs {
+		if lock.Specs[gem] != expected {
+			t.Errorf("Expected %s at %q, got %q", gem, expected, lock.Specs[gem])
+		}
+	}
+	if _, exists := lock.Specs["rack"]; exists {
+		t.Errorf("Expected dependency constraints not to be recorded as specs")
+	}
+
+	if lock.RubyVersion != "3.2.2" {
+		t.Errorf("Expected ruby version 3.2.2, got %q", lock.RubyVersion)
+	}
+	if lock.BundledWith != "2.4.10" {
+		t.Errorf("Expected bundler version 2.4.10, got %q", lock.BundledWith)
+	}
+}
+
+func TestLockfileCandidates(t *testing.T) {
+	tests := []struct {
+		name          string
+		gemfile       string
+		detect        func(fs.FS) []Candidate
+		expectValues  []string
+		expectSources []string
+	}{
+		{
+			name:          "rails from lockfile and Gemfile",
+			gemfile:       `gem "rails", "~> 7.1.0"`,
+			detect:        DetectRails,
+			expectValues:  []string{"7.1.3", "~> 7.1.0"},
+			expectSources: []string{"Gemfile.lock", "Gemfile"},
+		},
+		{
+			name:          "rails from lockfile without Gemfile constraint",
+			gemfile:       `gem "rails"`,
+			detect:        DetectRails,
+			expectValues:  []string{"7.1.3"},
+			expectSources: []string{"Gemfile.lock"},
+		},
+		{
+			name:          "ruby from lockfile",
+			detect:        DetectRubyVersion,
+			expectValues:  []string{"3.2.2"},
+			expectSources: []string{"Gemfile.lock"},
+		},
+		{
+			name:          "bundler from lockfile",
+			detect:        DetectBundler,
+			expectValues:  []string{"2.4.10"},
+			expectSources: []string{"Gemfile.lock"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+
+			if err := os.WriteFile(filepath.Join(tmpDir, "Gemfile.lock"), []byte(sampleGemfileLock), 0644); err != nil {
+				t.Fatalf("Failed to write Gemfile.lock: %v", err)
+			}
+			if tt.gemfile != "" {
+				if err := os.WriteFile(filepath.Join(tmpDir, "Gemfile"), []byte(tt.gemfile), 0644); err != nil {
+					t.Fatalf("Failed to write Gemfile: %v", err)
+				}
+			}
+
+			candidates := tt.detect(os.DirFS(tmpDir))
+
+			if len(candidates) != len(tt.expectValues) {
+				t.Fatalf("Expected %d candidates, got %d: %v", len(tt.expectValues), len(candidates), candidates)
+			}
+			for i, expected := range tt.expectValues {
+				if candidates[i].Value != expected {
+					t.Errorf("Expected value %q at index %d, got %q", expected, i, candidates[i].Value)
+				}
+				if candidates[i].Source != tt.expectSources[i] {
+					t.Errorf("Expected source %q at index %d, got %q", tt.expectSources[i], i, candidates[i].Source)
+				}
+			}
+		})
+	}
+}
@@ -1,22 +1,47 @@
 package detectors
 
 import (
-	"os"
+	"io/fs"
 	"regexp"
+
+	"github.com/stacktodate/stacktodate-cli/cmd/lib/versions"
 )
 
+func init() {
+	Register(FuncDetector{
+		ProductKey:    "go",
+		DisplayName:   "Go",
+		DetectFunc:    DetectGo,
+		ManifestNames: []string{"go.mod"},
+		ImageNames:    []string{"golang", "go"},
+	})
+}
+
 // DetectGo checks multiple sources for Go version
-func DetectGo() []Candidate {
+func DetectGo(fsys fs.FS) []Candidate {
 	var candidates []Candidate
 
 	// Check go.mod
-	if data, err := os.ReadFile("go.mod"); err == nil {
+	if data, err := fs.ReadFile(fsys, "go.mod"); err == nil {
 		content := string(data)
-		re := regexp.MustCompile(`go\s+(\d+\.\d+(?:\.\d+)?)`)
-		if matches := re.FindStringSubmatch(content); len(matches) > 1 {
+		re := regexp.MustCompile(`go\s+(\d+\.\d+(?:\.\d+)?(?:(?:rc|beta)\d+)?)`)
+		if matches := re.FindStringSubmatchIndex(content); matches != nil {
+			candidates = append(candidates, Candidate{
+				Value:  content[matches[2]:matches[3]],
+				Source: "go.mod",
+				Line:   lineAt(data, matches[2]),
+				Syntax: versions.GoToolchain,
+			})
+		}
+
+		// The toolchain directive names the Go release used to build the module
+		toolchainRe := regexp.MustCompile(`(?m)^toolchain\s+(go\d\S*)`)
+		if matches := toolchainRe.FindStringSubmatchIndex(content); matches != nil {
 			candidates = append(candidates, Candidate{
-				Value:  matches[1],
+				Value:  content[matches[2]:matches[3]],
 				Source: "go.mod",
+				Line:   lineAt(data, matches[2]),
+				Syntax: versions.GoToolchain,
 			})
 		}
 	}
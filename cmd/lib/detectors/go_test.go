@@ -2,7 +2,11 @@ package detectors
 
 import (
 	"os"
+	"path/filepath"
 	"testing"
+	"testing/fstest"
+
+	"github.com/stacktodate/stacktodate-cli/cmd/lib/versions"
 )
 
 func TestDetectGo(t *testing.T) {
@@ -58,22 +62,15 @@ require (
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tmpDir := t.TempDir()
-			oldWd, err := os.Getwd()
-			if err != nil {
-				t.Fatalf("Failed to get working directory: %v", err)
-			}
-			defer os.Chdir(oldWd)
-
-			os.Chdir(tmpDir)
 
 			if tt.goModContent != "" {
-				err := os.WriteFile("go.mod", []byte(tt.goModContent), 0644)
+				err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte(tt.goModContent), 0644)
 				if err != nil {
 					t.Fatalf("Failed to write go.mod: %v", err)
 				}
 			}
 
-			candidates := DetectGo()
+			candidates := DetectGo(os.DirFS(tmpDir))
 
 			if tt.expectFound {
 				if len(candidates) == 0 {
@@ -94,16 +91,30 @@ require (
 
 func TestDetectGoNoFile(t *testing.T) {
 	tmpDir := t.TempDir()
-	oldWd, err := os.Getwd()
-	if err != nil {
-		t.Fatalf("Failed to get working directory: %v", err)
-	}
-	defer os.Chdir(oldWd)
 
-	os.Chdir(tmpDir)
-
-	candidates := DetectGo()
+	candidates := DetectGo(os.DirFS(tmpDir))
 	if len(candidates) != 0 {
 		t.Errorf("Expected no candidates when go.mod doesn't exist, got %v", candidates)
 	}
 }
+
+func TestDetectGoToolchain(t *testing.T) {
+	fsys := fstest.MapFS{
+		"go.mod": {Data: []byte("module example.com\n\ngo 1.23rc1\n\ntoolchain go1.23.2\n")},
+	}
+
+	candidates := DetectGo(fsys)
+
+	expected := []string{"1.23rc1", "go1.23.2"}
+	if len(candidates) != len(expected) {
+		t.Fatalf("Expected %d candidates, got %d: %v", len(expected), len(candidates), candidates)
+	}
+	for i, value := range expected {
+		if candidates[i].Value != value || candidates[i].Syntax != versions.GoToolchain {
+			t.Errorf("Expected value %q at index %d, got %q", value, i, candidates[i].Value)
+		}
+	}
+	if candidates[1].Line != 5 {
+		t.Errorf("Expected toolchain on line 5, got %d", candidates[1].Line)
+	}
+}
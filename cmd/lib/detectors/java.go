@@ -0,0 +1,117 @@
+package detectors
+
+import (
+	"io/fs"
+	"regexp"
+	"strings"
+)
+
+func init() {
+	Register(FuncDetector{
+		ProductKey:    "java",
+		DisplayName:   "Java",
+		DetectFunc:    DetectJava,
+		ManifestNames: []string{".java-version", ".sdkmanrc", "pom.xml", "build.gradle", "build.gradle.kts"},
+		ImageNames:    []string{"eclipse-temurin", "openjdk", "amazoncorretto"},
+	})
+}
+
+// gradleBuildFiles lists the Gradle build scripts checked by the JVM detectors
+var gradleBuildFiles = []string{"build.gradle", "build.gradle.kts"}
+
+// DetectJava checks multiple sources for Java version
+func DetectJava(fsys fs.FS) []Candidate {
+	var candidates []Candidate
+
+	// Check .java-version
+	if data, err := fs.ReadFile(fsys, ".java-version"); err == nil {
+		if version := strings.TrimSpace(string(data)); version != "" {
+			candidates = append(candidates, Candidate{
+				Value:  normalizeJavaVersion(version),
+				Source: ".java-version",
+				Line:   lineOf(data, version),
+			})
+		}
+	}
+
+	// Check .sdkmanrc (e.g. java=17.0.9-tem)
+	if data, err := fs.ReadFile(fsys, ".sdkmanrc"); err == nil {
+		re := regexp.MustCompile(`(?m)^\s*java\s*=\s*([\d.]+)`)
+		if matches := re.FindStringSubmatch(string(data)); len(matches) > 1 {
+			candidates = append(candidates, Candidate{
+				Value:  normalizeJavaVersion(matches[1]),
+				Source: ".sdkmanrc",
+				Line:   lineOf(data, "java", matches[1]),
+			})
+		}
+	}
+
+	// Check pom.xml properties
+	if data, err := fs.ReadFile(fsys, "pom.xml"); err == nil {
+		for _, property := range []string{"maven.compiler.release", "java.version"} {
+			if version := findPomProperty(string(data), property); version != "" {
+				candidates = append(candidates, Candidate{
+					Value:  normalizeJavaVersion(version),
+					Source: "pom.xml",
+					Line:   lineOf(data, "<"+property+">"),
+				})
+				break
+			}
+		}
+	}
+
+	// Check Gradle toolchain and sourceCompatibility
+	toolchainRe := regexp.MustCompile(`languageVersion(?:\.set\(|\s*=)\s*JavaLanguageVersion\.of\(\s*['"]?(\d+)['"]?\s*\)`)
+	compatibilityRe := regexp.MustCompile(`sourceCompatibility\s*=\s*(?:JavaVersion\.VERSION_([\d_]+)|['"]?([\d.]+)['"]?)`)
+	for _, file := range gradleBuildFiles {
+		data, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			continue
+		}
+
+		content := string(data)
+		if matches := toolchainRe.FindStringSubmatch(content); len(matches) > 1 {
+			candidates = append(candidates, Candidate{
+				Value:  matches[1],
+				Source: file,
+				Line:   lineOf(data, matches[0]),
+			})
+		} else if matches := compatibilityRe.FindStringSubmatch(content); len(matches) > 2 {
+			version := matches[2]
+			if matches[1] != "" {
+				version = strings.ReplaceAll(matches[1], "_", ".")
+			}
+			candidates = append(candidates, Candidate{
+				Value:  normalizeJavaVersion(version),
+				Source: file,
+				Line:   lineOf(data, matches[0]),
+			})
+		}
+	}
+
+	return candidates
+}
+
+// findPomProperty returns the literal value of a Maven property, ignoring ${...} references
+func findPomProperty(content, property string) string {
+	re := regexp.MustCompile(`<` + regexp.QuoteMeta(property) + `>\s*([^<]+?)\s*</`)
+	matches := re.FindStringSubmatch(content)
+	if len(matches) < 2 || strings.HasPrefix(matches[1], "${") {
+		return ""
+	}
+	return matches[1]
+}
+
+// normalizeJavaVersion converts legacy 1.x version names to the modern scheme
+// Examples: 1.8 -> 8, 1.8.0_392 -> 8, 17.0.2 -> 17.0.2
+func normalizeJavaVersion(version string) string {
+	if strings.HasPrefix(version, "1.") {
+		parts := strings.FieldsFunc(version, func(r rune) bool {
+			return r == '.' || r == '_'
+		})
+		if len(parts) >= 2 {
+			return parts[1]
+		}
+	}
+	return version
+}
@@ -0,0 +1,127 @@
+package detectors
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDetectJava(t *testing.T) {
+	tests := []struct {
+		name          string
+		files         map[string]string
+		expectValues  []string
+		expectSources []string
+	}{
+		{
+			name:          "java version from .java-version",
+			files:         map[string]string{".java-version": "17.0.2\n"},
+			expectValues:  []string{"17.0.2"},
+			expectSources: []string{".java-version"},
+		},
+		{
+			name:          "java version from .sdkmanrc",
+			files:         map[string]string{".sdkmanrc": "# Enable auto-env\njava=21.0.1-tem\nmaven=3.9.5\n"},
+			expectValues:  []string{"21.0.1"},
+			expectSources: []string{".sdkmanrc"},
+		},
+		{
+			name: "maven.compiler.release in pom.xml",
+			files: map[string]string{"pom.xml": `<project>
+  <properties>
+    <maven.compiler.release>17</maven.compiler.release>
+  </properties>
+</project>`},
+			expectValues:  []string{"17"},
+			expectSources: []string{"pom.xml"},
+		},
+		{
+			name: "java.version in pom.xml with property reference",
+			files: map[string]string{"pom.xml": `<project>
+  <properties>
+    <java.version>1.8</java.version>
+    <maven.compiler.release>${java.version}</maven.compiler.release>
+  </properties>
+</project>`},
+			expectValues:  []string{"8"},
+			expectSources: []string{"pom.xml"},
+		},
+		{
+			name: "gradle toolchain",
+			files: map[string]string{"build.gradle.kts": `java {
+    toolchain {
+        languageVersion.set(JavaLanguageVersion.of(21))
+    }
+}`},
+			expectValues:  []string{"21"},
+			expectSources: []string{"build.gradle.kts"},
+		},
+		{
+			name:          "gradle sourceCompatibility with JavaVersion",
+			files:         map[string]string{"build.gradle": "sourceCompatibility = JavaVersion.VERSION_11\n"},
+			expectValues:  []string{"11"},
+			expectSources: []string{"build.gradle"},
+		},
+		{
+			name:          "gradle sourceCompatibility legacy string",
+			files:         map[string]string{"build.gradle": "sourceCompatibility = '1.8'\n"},
+			expectValues:  []string{"8"},
+			expectSources: []string{"build.gradle"},
+		},
+		{
+			name: "multiple sources",
+			files: map[string]string{
+				".java-version": "17",
+				"build.gradle":  "java { toolchain { languageVersion = JavaLanguageVersion.of(17) } }",
+			},
+			expectValues:  []string{"17", "17"},
+			expectSources: []string{".java-version", "build.gradle"},
+		},
+		{
+			name:  "pom.xml without java version",
+			files: map[string]string{"pom.xml": "<project><artifactId>app</artifactId></project>"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+
+			for file, content := range tt.files {
+				if err := os.WriteFile(filepath.Join(tmpDir, file), []byte(content), 0644); err != nil {
+					t.Fatalf("Failed to write %s: %v", file, err)
+				}
+			}
+
+			candidates := DetectJava(os.DirFS(tmpDir))
+
+			if len(candidates) != len(tt.expectValues) {
+				t.Fatalf("Expected %d candidates, got %d: %v", len(tt.expectValues), len(candidates), candidates)
+			}
+			for i, expected := range tt.expectValues {
+				if candidates[i].Value != expected {
+					t.Errorf("Expected value %q at index %d, got %q", expected, i, candidates[i].Value)
+				}
+				if candidates[i].Source != tt.expectSources[i] {
+					t.Errorf("Expected source %q at index %d, got %q", tt.expectSources[i], i, candidates[i].Source)
+				}
+			}
+		})
+	}
+}
+
+func TestNormalizeJavaVersion(t *testing.T) {
+	tests := map[string]string{
+		"1.8":       "8",
+		"1.8.0_392": "8",
+		"1.7":       "7",
+		"17":        "17",
+		"17.0.2":    "17.0.2",
+	}
+
+	for input, expected := range tests {
+		if result := normalizeJavaVersion(input); result != expected {
+			t.Errorf("normalizeJavaVersion(%q) = %q, want %q", input, result, expected)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package detectors
+
+import (
+	"io/fs"
+	"regexp"
+)
+
+func init() {
+	Register(FuncDetector{
+		ProductKey:    "kotlin",
+		DisplayName:   "Kotlin",
+		DetectFunc:    DetectKotlin,
+		ManifestNames: []string{"pom.xml", "build.gradle", "build.gradle.kts"},
+	})
+}
+
+// DetectKotlin checks Maven and Gradle build files for Kotlin
+func DetectKotlin(fsys fs.FS) []Candidate {
+	var candidates []Candidate
+
+	// Check pom.xml kotlin.version property
+	if data, err := fs.ReadFile(fsys, "pom.xml"); err == nil {
+		if version := findPomProperty(string(data), "kotlin.version"); version != "" {
+			candidates = append(candidates, Candidate{
+				Value:  version,
+				Source: "pom.xml",
+				Line:   lineOf(data, "<kotlin.version>"),
+			})
+		}
+	}
+
+	// Check Gradle plugin declaration, e.g. kotlin("jvm") version "1.9.22"
+	// or id 'org.jetbrains.kotlin.jvm' version '1.9.22'
+	re := regexp.MustCompile(`(?:kotlin\(\s*"[\w.-]+"\s*\)|id\s*\(?\s*['"]org\.jetbrains\.kotlin\.[\w.-]+['"]\s*\)?)\s+version\s+['"]([^'"]+)['"]`)
+	for _, file := range gradleBuildFiles {
+		data, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			continue
+		}
+
+		if matches := re.FindStringSubmatch(string(data)); len(matches) > 1 {
+			candidates = append(candidates, Candidate{
+				Value:  matches[1],
+				Source: file,
+				Line:   lineOf(data, matches[0]),
+			})
+		}
+	}
+
+	return candidates
+}
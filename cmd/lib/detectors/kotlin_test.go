@@ -0,0 +1,65 @@
+package detectors
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDetectKotlin(t *testing.T) {
+	tests := []struct {
+		name          string
+		files         map[string]string
+		expectValues  []string
+		expectSources []string
+	}{
+		{
+			name:          "kotlin.version in pom.xml",
+			files:         map[string]string{"pom.xml": "<project><properties><kotlin.version>1.9.22</kotlin.version></properties></project>"},
+			expectValues:  []string{"1.9.22"},
+			expectSources: []string{"pom.xml"},
+		},
+		{
+			name:          "kotlin dsl plugin shorthand",
+			files:         map[string]string{"build.gradle.kts": "plugins {\n    kotlin(\"jvm\") version \"1.9.21\"\n}\n"},
+			expectValues:  []string{"1.9.21"},
+			expectSources: []string{"build.gradle.kts"},
+		},
+		{
+			name:          "groovy plugin id",
+			files:         map[string]string{"build.gradle": "plugins {\n    id 'org.jetbrains.kotlin.jvm' version '2.0.0'\n}\n"},
+			expectValues:  []string{"2.0.0"},
+			expectSources: []string{"build.gradle"},
+		},
+		{
+			name:  "java only gradle build",
+			files: map[string]string{"build.gradle": "plugins {\n    id 'java'\n}\n"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+
+			for file, content := range tt.files {
+				if err := os.WriteFile(filepath.Join(tmpDir, file), []byte(content), 0644); err != nil {
+					t.Fatalf("Failed to write %s: %v", file, err)
+				}
+			}
+
+			candidates := DetectKotlin(os.DirFS(tmpDir))
+
+			if len(candidates) != len(tt.expectValues) {
+				t.Fatalf("Expected %d candidates, got %d: %v", len(tt.expectValues), len(candidates), candidates)
+			}
+			for i, expected := range tt.expectValues {
+				if candidates[i].Value != expected {
+					t.Errorf("Expected value %q at index %d, got %q", expected, i, candidates[i].Value)
+				}
+				if candidates[i].Source != tt.expectSources[i] {
+					t.Errorf("Expected source %q at index %d, got %q", tt.expectSources[i], i, candidates[i].Source)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,17 @@
+package detectors
+
+import "io/fs"
+
+func init() {
+	Register(FuncDetector{
+		ProductKey:    "laravel",
+		DisplayName:   "Laravel",
+		DetectFunc:    DetectLaravel,
+		ManifestNames: []string{"composer.json", "composer.lock"},
+	})
+}
+
+// DetectLaravel checks composer.lock and composer.json for Laravel
+func DetectLaravel(fsys fs.FS) []Candidate {
+	return detectComposerPackage(fsys, "laravel/framework")
+}
@@ -0,0 +1,83 @@
+package detectors
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDetectComposerFrameworks(t *testing.T) {
+	composerJSON := `{
+  "require": {
+    "php": "^8.1",
+    "laravel/framework": "^10.10",
+    "symfony/framework-bundle": "6.4.*"
+  }
+}`
+	composerLock := `{
+  "packages": [
+    {"name": "brick/math", "version": "0.11.0"},
+    {"name": "laravel/framework", "version": "v10.48.4"},
+    {"name": "symfony/framework-bundle", "version": "v6.4.3"}
+  ]
+}`
+
+	tests := []struct {
+		name          string
+		withLock      bool
+		detect        func(fs.FS) []Candidate
+		expectValues  []string
+		expectSources []string
+	}{
+		{
+			name:          "laravel from lock and manifest",
+			withLock:      true,
+			detect:        DetectLaravel,
+			expectValues:  []string{"10.48.4", "^10.10"},
+			expectSources: []string{"composer.lock", "composer.json"},
+		},
+		{
+			name:          "laravel from manifest only",
+			detect:        DetectLaravel,
+			expectValues:  []string{"^10.10"},
+			expectSources: []string{"composer.json"},
+		},
+		{
+			name:          "symfony from lock and manifest",
+			withLock:      true,
+			detect:        DetectSymfony,
+			expectValues:  []string{"6.4.3", "6.4.*"},
+			expectSources: []string{"composer.lock", "composer.json"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+
+			if err := os.WriteFile(filepath.Join(tmpDir, "composer.json"), []byte(composerJSON), 0644); err != nil {
+				t.Fatalf("Failed to write composer.json: %v", err)
+			}
+			if tt.withLock {
+				if err := os.WriteFile(filepath.Join(tmpDir, "composer.lock"), []byte(composerLock), 0644); err != nil {
+					t.Fatalf("Failed to write composer.lock: %v", err)
+				}
+			}
+
+			candidates := tt.detect(os.DirFS(tmpDir))
+
+			if len(candidates) != len(tt.expectValues) {
+				t.Fatalf("Expected %d candidates, got %d: %v", len(tt.expectValues), len(candidates), candidates)
+			}
+			for i, expected := range tt.expectValues {
+				if candidates[i].Value != expected {
+					t.Errorf("Expected value %q at index %d, got %q", expected, i, candidates[i].Value)
+				}
+				if candidates[i].Source != tt.expectSources[i] {
+					t.Errorf("Expected source %q at index %d, got %q", tt.expectSources[i], i, candidates[i].Source)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package detectors
+
+import (
+	"io/fs"
+
+	"github.com/stacktodate/stacktodate-cli/cmd/lib/versions"
+)
+
+// nodeFrameworks lists the frameworks detected from npm packages
+var nodeFrameworks = []struct {
+	key     string
+	name    string
+	pkgName string
+}{
+	{"angular", "Angular", "@angular/core"},
+	{"nextjs", "Next.js", "next"},
+	{"nuxt", "Nuxt", "nuxt"},
+	{"react", "React", "react"},
+	{"vue", "Vue", "vue"},
+}
+
+func init() {
+	for _, framework := range nodeFrameworks {
+		pkgName := framework.pkgName
+		Register(FuncDetector{
+			ProductKey:  framework.key,
+			DisplayName: framework.name,
+			DetectFunc: func(fsys fs.FS) []Candidate {
+				return detectNodePackage(fsys, pkgName)
+			},
+			ManifestNames: []string{"package.json"},
+		})
+	}
+}
+
+// detectNodePackage returns candidates for an npm package, preferring the installed
+// version from the lockfile over the constraint in package.json
+func detectNodePackage(fsys fs.FS, name string) []Candidate {
+	var candidates []Candidate
+
+	// Check lockfiles
+	if version, source := lockedNodePackage(fsys, name); version != "" {
+		candidates = append(candidates, Candidate{
+			Value:  version,
+			Source: source,
+			Line:   lineOfAfter(fsys, source, name, version),
+		})
+	}
+
+	// Check package.json dependencies
+	if version := packageJSONDependency(fsys, name); version != "" {
+		candidates = append(candidates, Candidate{
+			Value:  version,
+			Source: "package.json",
+			Line:   fileLineOf(fsys, "package.json", `"`+name+`"`, version),
+			Syntax: versions.NPM,
+		})
+	}
+
+	return candidates
+}
@@ -1,33 +1,48 @@
 package detectors
 
 import (
-	"os"
+	"io/fs"
 	"regexp"
 	"strings"
+
+	"github.com/stacktodate/stacktodate-cli/cmd/lib/versions"
 )
 
+func init() {
+	Register(FuncDetector{
+		ProductKey:    "nodejs",
+		DisplayName:   "Node.js",
+		DetectFunc:    DetectNode,
+		ManifestNames: []string{"package.json", ".nvmrc"},
+		ImageNames:    []string{"node"},
+	})
+}
+
 // DetectNode checks multiple sources for Node.js version
-func DetectNode() []Candidate {
+func DetectNode(fsys fs.FS) []Candidate {
 	var candidates []Candidate
 
 	// Check package.json engines.node
-	if data, err := os.ReadFile("package.json"); err == nil {
+	if data, err := fs.ReadFile(fsys, "package.json"); err == nil {
 		content := string(data)
 		re := regexp.MustCompile(`"node"\s*:\s*"([^"]+)"`)
 		if matches := re.FindStringSubmatch(content); len(matches) > 1 {
 			candidates = append(candidates, Candidate{
 				Value:  matches[1],
 				Source: "package.json",
+				Line:   lineOf(data, matches[0]),
+				Syntax: versions.NPM,
 			})
 		}
 	}
 
 	// Check .nvmrc
-	if data, err := os.ReadFile(".nvmrc"); err == nil {
+	if data, err := fs.ReadFile(fsys, ".nvmrc"); err == nil {
 		if version := strings.TrimSpace(string(data)); version != "" {
 			candidates = append(candidates, Candidate{
 				Value:  version,
 				Source: ".nvmrc",
+				Line:   lineOf(data, version),
 			})
 		}
 	}
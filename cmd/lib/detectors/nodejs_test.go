@@ -2,6 +2,7 @@ package detectors
 
 import (
 	"os"
+	"path/filepath"
 	"testing"
 )
 
@@ -67,29 +68,22 @@ func TestDetectNode(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tmpDir := t.TempDir()
-			oldWd, err := os.Getwd()
-			if err != nil {
-				t.Fatalf("Failed to get working directory: %v", err)
-			}
-			defer os.Chdir(oldWd)
-
-			os.Chdir(tmpDir)
 
 			if tt.packageJsonContent != "" {
-				err := os.WriteFile("package.json", []byte(tt.packageJsonContent), 0644)
+				err := os.WriteFile(filepath.Join(tmpDir, "package.json"), []byte(tt.packageJsonContent), 0644)
 				if err != nil {
 					t.Fatalf("Failed to write package.json: %v", err)
 				}
 			}
 
 			if tt.nvmrcContent != "" {
-				err := os.WriteFile(".nvmrc", []byte(tt.nvmrcContent), 0644)
+				err := os.WriteFile(filepath.Join(tmpDir, ".nvmrc"), []byte(tt.nvmrcContent), 0644)
 				if err != nil {
 					t.Fatalf("Failed to write .nvmrc: %v", err)
 				}
 			}
 
-			candidates := DetectNode()
+			candidates := DetectNode(os.DirFS(tmpDir))
 
 			if tt.expectFound {
 				if len(candidates) != len(tt.expectValues) {
@@ -117,15 +111,8 @@ func TestDetectNode(t *testing.T) {
 
 func TestDetectNodeNoFiles(t *testing.T) {
 	tmpDir := t.TempDir()
-	oldWd, err := os.Getwd()
-	if err != nil {
-		t.Fatalf("Failed to get working directory: %v", err)
-	}
-	defer os.Chdir(oldWd)
-
-	os.Chdir(tmpDir)
 
-	candidates := DetectNode()
+	candidates := DetectNode(os.DirFS(tmpDir))
 	if len(candidates) != 0 {
 		t.Errorf("Expected no candidates when files don't exist, got %v", candidates)
 	}
@@ -0,0 +1,164 @@
+package detectors
+
+import (
+	"bufio"
+	"encoding/json"
+	"io/fs"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// lockedNodePackage returns the installed version of an npm package and the lockfile
+// it came from, checking package-lock.json, yarn.lock and pnpm-lock.yaml in that order
+func lockedNodePackage(fsys fs.FS, name string) (version, source string) {
+	if version := packageLockVersion(fsys, name); version != "" {
+		return version, "package-lock.json"
+	}
+	if version := yarnLockVersion(fsys, name); version != "" {
+		return version, "yarn.lock"
+	}
+	if version := pnpmLockVersion(fsys, name); version != "" {
+		return version, "pnpm-lock.yaml"
+	}
+	return "", ""
+}
+
+// packageLockVersion reads package-lock.json (lockfileVersion 1, 2 and 3)
+func packageLockVersion(fsys fs.FS, name string) string {
+	data, err := fs.ReadFile(fsys, "package-lock.json")
+	if err != nil {
+		return ""
+	}
+
+	type lockedPackage struct {
+		Version string `json:"version"`
+	}
+	var lock struct {
+		Packages     map[string]lockedPackage `json:"packages"`
+		Dependencies map[string]lockedPackage `json:"dependencies"`
+	}
+	if err := json.Unmarshal(data, &lock); err != nil {
+		return ""
+	}
+
+	if pkg, ok := lock.Packages["node_modules/"+name]; ok && pkg.Version != "" {
+		return pkg.Version
+	}
+	return lock.Dependencies[name].Version
+}
+
+// yarnLockVersion reads yarn.lock in both the classic and the berry format
+// Examples: react@^18.2.0: / version "18.2.0", "react@npm:^18.2.0": / version: 18.2.0
+func yarnLockVersion(fsys fs.FS, name string) string {
+	data, err := fs.ReadFile(fsys, "yarn.lock")
+	if err != nil {
+		return ""
+	}
+
+	matched := false
+	scanner := bufio.NewScanner(strings.NewReader(string(data)))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		// Unindented lines list the descriptors resolved by the entry that follows
+		if !strings.HasPrefix(line, " ") {
+			matched = false
+			for _, descriptor := range strings.Split(strings.TrimSuffix(line, ":"), ",") {
+				descriptor = strings.Trim(strings.TrimSpace(descriptor), `"`)
+				if strings.HasPrefix(descriptor, name+"@") {
+					matched = true
+					break
+				}
+			}
+			continue
+		}
+
+		if !matched {
+			continue
+		}
+
+		field := strings.TrimSpace(line)
+		if strings.HasPrefix(field, "version") {
+			value := strings.TrimPrefix(field, "version")
+			value = strings.TrimPrefix(strings.TrimSpace(value), ":")
+			return strings.Trim(strings.TrimSpace(value), `"`)
+		}
+	}
+
+	return ""
+}
+
+// pnpmLockVersion reads the root importer dependencies of pnpm-lock.yaml
+func pnpmLockVersion(fsys fs.FS, name string) string {
+	data, err := fs.ReadFile(fsys, "pnpm-lock.yaml")
+	if err != nil {
+		return ""
+	}
+
+	type dependencies struct {
+		Dependencies    map[string]interface{} `yaml:"dependencies"`
+		DevDependencies map[string]interface{} `yaml:"devDependencies"`
+	}
+	var lock struct {
+		Dependencies    map[string]interface{}  `yaml:"dependencies"`
+		DevDependencies map[string]interface{}  `yaml:"devDependencies"`
+		Importers       map[string]dependencies `yaml:"importers"`
+	}
+	if err := yaml.Unmarshal(data, &lock); err != nil {
+		return ""
+	}
+
+	// Single-project lockfiles list dependencies at the top level,
+	// workspaces (and lockfile v9) under the "." importer
+	root := dependencies{Dependencies: lock.Dependencies, DevDependencies: lock.DevDependencies}
+	if importer, ok := lock.Importers["."]; ok {
+		root = importer
+	}
+
+	for _, deps := range []map[string]interface{}{root.Dependencies, root.DevDependencies} {
+		var version string
+		switch value := deps[name].(type) {
+		case string:
+			// lockfile v5: react: 18.2.0
+			version = value
+		case map[string]interface{}:
+			// lockfile v6+: react: {specifier: ^18.2.0, version: 18.2.0}
+			version, _ = value["version"].(string)
+		}
+
+		// Strip peer dependency suffixes, e.g. 14.1.0(react@18.2.0)
+		if idx := strings.Index(version, "("); idx >= 0 {
+			version = version[:idx]
+		}
+		if version != "" {
+			return version
+		}
+	}
+
+	return ""
+}
+
+// packageJSONDependency returns the version constraint of a package in package.json
+func packageJSONDependency(fsys fs.FS, name string) string {
+	data, err := fs.ReadFile(fsys, "package.json")
+	if err != nil {
+		return ""
+	}
+
+	var manifest struct {
+		Dependencies    map[string]string `json:"dependencies"`
+		DevDependencies map[string]string `json:"devDependencies"`
+	}
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return ""
+	}
+
+	if version := manifest.Dependencies[name]; version != "" {
+		return version
+	}
+	return manifest.DevDependencies[name]
+}
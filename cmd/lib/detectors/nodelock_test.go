@@ -0,0 +1,147 @@
+package detectors
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDetectNodePackage(t *testing.T) {
+	packageJSON := `{"dependencies": {"react": "^18.2.0", "@angular/core": "^17.0.0"}, "devDependencies": {"vue": "~3.4.0"}}`
+
+	tests := []struct {
+		name          string
+		files         map[string]string
+		pkgName       string
+		expectValues  []string
+		expectSources []string
+	}{
+		{
+			name: "package-lock.json v3",
+			files: map[string]string{
+				"package.json": packageJSON,
+				"package-lock.json": `{"lockfileVersion": 3, "packages": {
+  "": {"dependencies": {"react": "^18.2.0"}},
+  "node_modules/react": {"version": "18.2.0"}
+}}`,
+			},
+			pkgName:       "react",
+			expectValues:  []string{"18.2.0", "^18.2.0"},
+			expectSources: []string{"package-lock.json", "package.json"},
+		},
+		{
+			name: "package-lock.json v1",
+			files: map[string]string{
+				"package-lock.json": `{"lockfileVersion": 1, "dependencies": {"react": {"version": "16.14.0"}}}`,
+			},
+			pkgName:       "react",
+			expectValues:  []string{"16.14.0"},
+			expectSources: []string{"package-lock.json"},
+		},
+		{
+			name: "yarn classic lockfile",
+			files: map[string]string{
+				"package.json": packageJSON,
+				"yarn.lock": `# THIS IS AN AUTOGENERATED FILE.
+# yarn lockfile v1
+
+
+"@angular/common@^17.0.0":
+  version "17.0.8"
+
+"@angular/core@^17.0.0", "@angular/core@~17.0.0":
+  version "17.0.9"
+  resolved "https://registry.yarnpkg.com/@angular/core/-/core-17.0.9.tgz"
+`,
+			},
+			pkgName:       "@angular/core",
+			expectValues:  []string{"17.0.9", "^17.0.0"},
+			expectSources: []string{"yarn.lock", "package.json"},
+		},
+		{
+			name: "yarn berry lockfile",
+			files: map[string]string{
+				"yarn.lock": `__metadata:
+  version: 6
+
+"vue@npm:~3.4.0":
+  version: 3.4.21
+  resolution: "vue@npm:3.4.21"
+`,
+			},
+			pkgName:       "vue",
+			expectValues:  []string{"3.4.21"},
+			expectSources: []string{"yarn.lock"},
+		},
+		{
+			name: "pnpm lockfile v6",
+			files: map[string]string{
+				"pnpm-lock.yaml": `lockfileVersion: '6.0'
+
+dependencies:
+  next:
+    specifier: ^14.1.0
+    version: 14.1.0(react-dom@18.2.0)(react@18.2.0)
+`,
+			},
+			pkgName:       "next",
+			expectValues:  []string{"14.1.0"},
+			expectSources: []string{"pnpm-lock.yaml"},
+		},
+		{
+			name: "pnpm lockfile v9 importers",
+			files: map[string]string{
+				"pnpm-lock.yaml": `lockfileVersion: '9.0'
+
+importers:
+  .:
+    devDependencies:
+      nuxt:
+        specifier: ^3.10.0
+        version: 3.10.3
+`,
+			},
+			pkgName:       "nuxt",
+			expectValues:  []string{"3.10.3"},
+			expectSources: []string{"pnpm-lock.yaml"},
+		},
+		{
+			name:          "package.json only",
+			files:         map[string]string{"package.json": packageJSON},
+			pkgName:       "vue",
+			expectValues:  []string{"~3.4.0"},
+			expectSources: []string{"package.json"},
+		},
+		{
+			name:    "package not used",
+			files:   map[string]string{"package.json": packageJSON},
+			pkgName: "next",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+
+			for file, content := range tt.files {
+				if err := os.WriteFile(filepath.Join(tmpDir, file), []byte(content), 0644); err != nil {
+					t.Fatalf("Failed to write %s: %v", file, err)
+				}
+			}
+
+			candidates := detectNodePackage(os.DirFS(tmpDir), tt.pkgName)
+
+			if len(candidates) != len(tt.expectValues) {
+				t.Fatalf("Expected %d candidates, got %d: %v", len(tt.expectValues), len(candidates), candidates)
+			}
+			for i, expected := range tt.expectValues {
+				if candidates[i].Value != expected {
+					t.Errorf("Expected value %q at index %d, got %q", expected, i, candidates[i].Value)
+				}
+				if candidates[i].Source != tt.expectSources[i] {
+					t.Errorf("Expected source %q at index %d, got %q", tt.expectSources[i], i, candidates[i].Source)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package detectors
+
+import (
+	"encoding/json"
+	"io/fs"
+	"strings"
+
+	"github.com/stacktodate/stacktodate-cli/cmd/lib/versions"
+)
+
+func init() {
+	Register(FuncDetector{
+		ProductKey:    "php",
+		DisplayName:   "PHP",
+		DetectFunc:    DetectPHP,
+		ManifestNames: []string{"composer.json"},
+		ImageNames:    []string{"php"},
+	})
+}
+
+// composerManifest holds the parts of composer.json used for detection
+type composerManifest struct {
+	Require map[string]string `json:"require"`
+	Config  struct {
+		Platform map[string]string `json:"platform"`
+	} `json:"config"`
+}
+
+// composerLock holds the resolved packages of composer.lock
+type composerLock struct {
+	Packages []struct {
+		Name    string `json:"name"`
+		Version string `json:"version"`
+	} `json:"packages"`
+}
+
+// DetectPHP checks composer.json for the PHP version
+func DetectPHP(fsys fs.FS) []Candidate {
+	var candidates []Candidate
+
+	manifest, err := readComposerManifest(fsys)
+	if err != nil {
+		return candidates
+	}
+
+	// Check require.php constraint
+	if version := strings.TrimSpace(manifest.Require["php"]); version != "" {
+		candidates = append(candidates, Candidate{
+			Value:  version,
+			Source: "composer.json",
+			Line:   fileLineOf(fsys, "composer.json", `"php"`, version),
+			Syntax: versions.NPM,
+		})
+	}
+
+	// Check config.platform.php, which pins the PHP version used for resolution
+	if version := strings.TrimSpace(manifest.Config.Platform["php"]); version != "" {
+		candidates = append(candidates, Candidate{
+			Value:  version,
+			Source: "composer.json",
+			Line:   lineOfAfter(fsys, "composer.json", `"platform"`, `"php"`),
+		})
+	}
+
+	return candidates
+}
+
+// detectComposerPackage returns candidates for a Composer package, preferring
+// the resolved version in composer.lock over the constraint in composer.json
+func detectComposerPackage(fsys fs.FS, name string) []Candidate {
+	var candidates []Candidate
+
+	// Check composer.lock resolved version
+	if data, err := fs.ReadFile(fsys, "composer.lock"); err == nil {
+		var lock composerLock
+		if err := json.Unmarshal(data, &lock); err == nil {
+			for _, pkg := range lock.Packages {
+				if pkg.Name == name && pkg.Version != "" {
+					candidates = append(candidates, Candidate{
+						Value:  strings.TrimPrefix(pkg.Version, "v"),
+						Source: "composer.lock",
+						Line:   lineOfAfter(fsys, "composer.lock", `"`+name+`"`, `"version"`),
+					})
+					break
+				}
+			}
+		}
+	}
+
+	// Check composer.json constraint
+	if manifest, err := readComposerManifest(fsys); err == nil {
+		if version := strings.TrimSpace(manifest.Require[name]); version != "" {
+			candidates = append(candidates, Candidate{
+				Value:  version,
+				Source: "composer.json",
+				Line:   fileLineOf(fsys, "composer.json", `"`+name+`"`, version),
+				Syntax: versions.NPM,
+			})
+		}
+	}
+
+	return candidates
+}
+
+// readComposerManifest parses composer.json at the root of fsys
+func readComposerManifest(fsys fs.FS) (*composerManifest, error) {
+	data, err := fs.ReadFile(fsys, "composer.json")
+	if err != nil {
+		return nil, err
+	}
+
+	var manifest composerManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, err
+	}
+
+	return &manifest, nil
+}
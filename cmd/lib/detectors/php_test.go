@@ -0,0 +1,73 @@
+package detectors
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDetectPHP(t *testing.T) {
+	tests := []struct {
+		name            string
+		composerContent string
+		expectValues    []string
+		expectSources   []string
+	}{
+		{
+			name:            "require php constraint",
+			composerContent: `{"require": {"php": "^8.1", "laravel/framework": "^10.10"}}`,
+			expectValues:    []string{"^8.1"},
+			expectSources:   []string{"composer.json"},
+		},
+		{
+			name: "require and platform php",
+			composerContent: `{
+  "require": {"php": ">=8.1"},
+  "config": {"platform": {"php": "8.2.12"}}
+}`,
+			expectValues:  []string{">=8.1", "8.2.12"},
+			expectSources: []string{"composer.json", "composer.json"},
+		},
+		{
+			name:            "no php requirement",
+			composerContent: `{"require": {"monolog/monolog": "^3.0"}}`,
+		},
+		{
+			name:            "invalid composer.json",
+			composerContent: `{"require": `,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+
+			if err := os.WriteFile(filepath.Join(tmpDir, "composer.json"), []byte(tt.composerContent), 0644); err != nil {
+				t.Fatalf("Failed to write composer.json: %v", err)
+			}
+
+			candidates := DetectPHP(os.DirFS(tmpDir))
+
+			if len(candidates) != len(tt.expectValues) {
+				t.Fatalf("Expected %d candidates, got %d: %v", len(tt.expectValues), len(candidates), candidates)
+			}
+			for i, expected := range tt.expectValues {
+				if candidates[i].Value != expected {
+					t.Errorf("Expected value %q at index %d, got %q", expected, i, candidates[i].Value)
+				}
+				if candidates[i].Source != tt.expectSources[i] {
+					t.Errorf("Expected source %q at index %d, got %q", tt.expectSources[i], i, candidates[i].Source)
+				}
+			}
+		})
+	}
+}
+
+func TestDetectPHPNoFiles(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	candidates := DetectPHP(os.DirFS(tmpDir))
+	if len(candidates) != 0 {
+		t.Errorf("Expected no candidates when composer.json doesn't exist, got %v", candidates)
+	}
+}
@@ -1,45 +1,61 @@
 package detectors
 
 import (
-	"os"
+	"io/fs"
 	"regexp"
 	"strings"
+
+	"github.com/stacktodate/stacktodate-cli/cmd/lib/versions"
 )
 
+func init() {
+	Register(FuncDetector{
+		ProductKey:    "python",
+		DisplayName:   "Python",
+		DetectFunc:    DetectPython,
+		ManifestNames: []string{".python-version", "pyproject.toml", "Pipfile"},
+		ImageNames:    []string{"python"},
+	})
+}
+
 // DetectPython checks multiple sources for Python version
-func DetectPython() []Candidate {
+func DetectPython(fsys fs.FS) []Candidate {
 	var candidates []Candidate
 
 	// Check .python-version
-	if data, err := os.ReadFile(".python-version"); err == nil {
+	if data, err := fs.ReadFile(fsys, ".python-version"); err == nil {
 		if version := strings.TrimSpace(string(data)); version != "" {
 			candidates = append(candidates, Candidate{
 				Value:  version,
 				Source: ".python-version",
+				Line:   lineOf(data, version),
 			})
 		}
 	}
 
 	// Check pyproject.toml
-	if data, err := os.ReadFile("pyproject.toml"); err == nil {
+	if data, err := fs.ReadFile(fsys, "pyproject.toml"); err == nil {
 		content := string(data)
 		re := regexp.MustCompile(`python\s*=\s*"([^"]+)"`)
 		if matches := re.FindStringSubmatch(content); len(matches) > 1 {
 			candidates = append(candidates, Candidate{
 				Value:  matches[1],
 				Source: "pyproject.toml",
+				Line:   lineOf(data, matches[0]),
+				Syntax: versions.PEP440,
 			})
 		}
 	}
 
 	// Check Pipfile
-	if data, err := os.ReadFile("Pipfile"); err == nil {
+	if data, err := fs.ReadFile(fsys, "Pipfile"); err == nil {
 		content := string(data)
 		re := regexp.MustCompile(`python_version\s*=\s*"([^"]+)"`)
 		if matches := re.FindStringSubmatch(content); len(matches) > 1 {
 			candidates = append(candidates, Candidate{
 				Value:  matches[1],
 				Source: "Pipfile",
+				Line:   lineOf(data, matches[0]),
 			})
 		}
 	}
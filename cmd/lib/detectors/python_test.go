@@ -2,6 +2,7 @@ package detectors
 
 import (
 	"os"
+	"path/filepath"
 	"testing"
 )
 
@@ -79,36 +80,29 @@ python = "3.7.0"
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tmpDir := t.TempDir()
-			oldWd, err := os.Getwd()
-			if err != nil {
-				t.Fatalf("Failed to get working directory: %v", err)
-			}
-			defer os.Chdir(oldWd)
-
-			os.Chdir(tmpDir)
 
 			if tt.pythonVersionFile != "" {
-				err := os.WriteFile(".python-version", []byte(tt.pythonVersionFile), 0644)
+				err := os.WriteFile(filepath.Join(tmpDir, ".python-version"), []byte(tt.pythonVersionFile), 0644)
 				if err != nil {
 					t.Fatalf("Failed to write .python-version: %v", err)
 				}
 			}
 
 			if tt.pyprojectContent != "" {
-				err := os.WriteFile("pyproject.toml", []byte(tt.pyprojectContent), 0644)
+				err := os.WriteFile(filepath.Join(tmpDir, "pyproject.toml"), []byte(tt.pyprojectContent), 0644)
 				if err != nil {
 					t.Fatalf("Failed to write pyproject.toml: %v", err)
 				}
 			}
 
 			if tt.pipfileContent != "" {
-				err := os.WriteFile("Pipfile", []byte(tt.pipfileContent), 0644)
+				err := os.WriteFile(filepath.Join(tmpDir, "Pipfile"), []byte(tt.pipfileContent), 0644)
 				if err != nil {
 					t.Fatalf("Failed to write Pipfile: %v", err)
 				}
 			}
 
-			candidates := DetectPython()
+			candidates := DetectPython(os.DirFS(tmpDir))
 
 			if tt.expectFound {
 				if len(candidates) != len(tt.expectValues) {
@@ -136,15 +130,8 @@ python = "3.7.0"
 
 func TestDetectPythonNoFiles(t *testing.T) {
 	tmpDir := t.TempDir()
-	oldWd, err := os.Getwd()
-	if err != nil {
-		t.Fatalf("Failed to get working directory: %v", err)
-	}
-	defer os.Chdir(oldWd)
-
-	os.Chdir(tmpDir)
 
-	candidates := DetectPython()
+	candidates := DetectPython(os.DirFS(tmpDir))
 	if len(candidates) != 0 {
 		t.Errorf("Expected no candidates when files don't exist, got %v", candidates)
 	}
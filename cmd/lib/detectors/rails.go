@@ -1,22 +1,51 @@
 package detectors
 
 import (
-	"os"
+	"io/fs"
 	"regexp"
+
+	"github.com/stacktodate/stacktodate-cli/cmd/lib/versions"
 )
 
+func init() {
+	Register(FuncDetector{
+		ProductKey:    "rails",
+		DisplayName:   "Rails",
+		DetectFunc:    DetectRails,
+		ManifestNames: []string{"Gemfile", "Gemfile.lock"},
+	})
+}
+
 // DetectRails checks multiple sources for Rails
-func DetectRails() []Candidate {
+func DetectRails(fsys fs.FS) []Candidate {
 	var candidates []Candidate
 
-	// Check Gemfile
-	if data, err := os.ReadFile("Gemfile"); err == nil {
+	// Check Gemfile.lock resolved version, which is authoritative
+	if lock, err := readGemfileLock(fsys); err == nil {
+		gem := "rails"
+		if lock.Specs[gem] == "" {
+			// Apps may depend on the individual framework gems instead of rails
+			gem = "railties"
+		}
+		if version := lock.Specs[gem]; version != "" {
+			candidates = append(candidates, Candidate{
+				Value:  version,
+				Source: "Gemfile.lock",
+				Line:   lock.SpecLines[gem],
+			})
+		}
+	}
+
+	// Check Gemfile constraint
+	if data, err := fs.ReadFile(fsys, "Gemfile"); err == nil {
 		content := string(data)
 		re := regexp.MustCompile(`gem ['"]rails['"],\s*['"]([^'"]+)['"]`)
 		if matches := re.FindStringSubmatch(content); len(matches) > 1 {
 			candidates = append(candidates, Candidate{
 				Value:  matches[1],
 				Source: "Gemfile",
+				Line:   lineOf(data, matches[0]),
+				Syntax: versions.Gem,
 			})
 		}
 	}
@@ -2,6 +2,7 @@ package detectors
 
 import (
 	"os"
+	"path/filepath"
 	"testing"
 )
 
@@ -55,22 +56,15 @@ gem 'sqlite3'
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tmpDir := t.TempDir()
-			oldWd, err := os.Getwd()
-			if err != nil {
-				t.Fatalf("Failed to get working directory: %v", err)
-			}
-			defer os.Chdir(oldWd)
-
-			os.Chdir(tmpDir)
 
 			if tt.gemfileContent != "" {
-				err := os.WriteFile("Gemfile", []byte(tt.gemfileContent), 0644)
+				err := os.WriteFile(filepath.Join(tmpDir, "Gemfile"), []byte(tt.gemfileContent), 0644)
 				if err != nil {
 					t.Fatalf("Failed to write Gemfile: %v", err)
 				}
 			}
 
-			candidates := DetectRails()
+			candidates := DetectRails(os.DirFS(tmpDir))
 
 			if tt.expectFound {
 				if len(candidates) == 0 {
@@ -91,15 +85,8 @@ gem 'sqlite3'
 
 func TestDetectRailsNoFile(t *testing.T) {
 	tmpDir := t.TempDir()
-	oldWd, err := os.Getwd()
-	if err != nil {
-		t.Fatalf("Failed to get working directory: %v", err)
-	}
-	defer os.Chdir(oldWd)
-
-	os.Chdir(tmpDir)
 
-	candidates := DetectRails()
+	candidates := DetectRails(os.DirFS(tmpDir))
 	if len(candidates) != 0 {
 		t.Errorf("Expected no candidates when Gemfile doesn't exist, got %v", candidates)
 	}
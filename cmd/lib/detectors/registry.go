@@ -0,0 +1,190 @@
+package detectors
+
+import (
+	"fmt"
+	"io/fs"
+	"sort"
+	"strings"
+)
+
+// Detector finds version candidates for a single product of the catalog
+type Detector interface {
+	// Key returns the catalog product key (e.g. "ruby", "nodejs")
+	Key() string
+	// Name returns the display name used in output (e.g. "Node.js")
+	Name() string
+	// Detect returns all version candidates found at the root of fsys
+	Detect(fsys fs.FS) []Candidate
+}
+
+// ImageDetector is implemented by detectors whose product version can also
+// be read from the tag of a Docker base image
+type ImageDetector interface {
+	Detector
+	// Images returns the image repositories (without tag) carrying the product version
+	Images() []string
+}
+
+// Source detects version candidates for several products from a single file
+// format, such as the asdf .tool-versions file
+type Source interface {
+	// Name returns the name of the source used in output
+	Name() string
+	// Detect returns all candidates found at the root of fsys keyed by catalog product key
+	Detect(fsys fs.FS) map[string][]Candidate
+}
+
+// ManifestLister is implemented by detectors and sources that know which
+// files mark the root of a project they can detect
+type ManifestLister interface {
+	// Manifests returns file name patterns (as accepted by path.Match) marking a project root
+	Manifests() []string
+}
+
+// FuncDetector is a Detector backed by a plain detection function
+type FuncDetector struct {
+	ProductKey    string
+	DisplayName   string
+	DetectFunc    func(fsys fs.FS) []Candidate
+	ImageNames    []string
+	ManifestNames []string
+}
+
+// Key returns the catalog product key
+func (d FuncDetector) Key() string {
+	return d.ProductKey
+}
+
+// Name returns the display name
+func (d FuncDetector) Name() string {
+	return d.DisplayName
+}
+
+// Detect runs the detection function
+func (d FuncDetector) Detect(fsys fs.FS) []Candidate {
+	return d.DetectFunc(fsys)
+}
+
+// Images returns the Docker image repositories for the product
+func (d FuncDetector) Images() []string {
+	return d.ImageNames
+}
+
+// Manifests returns the file name patterns marking a project root
+func (d FuncDetector) Manifests() []string {
+	return d.ManifestNames
+}
+
+// FuncSource is a Source backed by a plain detection function
+type FuncSource struct {
+	SourceName    string
+	DetectFunc    func(fsys fs.FS) map[string][]Candidate
+	ManifestNames []string
+}
+
+// Name returns the name of the source
+func (s FuncSource) Name() string {
+	return s.SourceName
+}
+
+// Detect runs the detection function
+func (s FuncSource) Detect(fsys fs.FS) map[string][]Candidate {
+	return s.DetectFunc(fsys)
+}
+
+// Manifests returns the file name patterns marking a project root
+func (s FuncSource) Manifests() []string {
+	return s.ManifestNames
+}
+
+var registry = make(map[string]Detector)
+
+var sources []Source
+
+// Register adds a detector to the registry.
+// It panics if a detector is already registered for the same product key.
+func Register(d Detector) {
+	if _, exists := registry[d.Key()]; exists {
+		panic(fmt.Sprintf("detectors: detector already registered for %q", d.Key()))
+	}
+	registry[d.Key()] = d
+}
+
+// All returns every registered detector sorted by product key
+func All() []Detector {
+	all := make([]Detector, 0, len(registry))
+	for _, d := range registry {
+		all = append(all, d)
+	}
+
+	sort.Slice(all, func(i, j int) bool {
+		return all[i].Key() < all[j].Key()
+	})
+
+	return all
+}
+
+// RegisterSource adds a multi-product source to the registry
+func RegisterSource(s Source) {
+	sources = append(sources, s)
+	sort.Slice(sources, func(i, j int) bool {
+		return sources[i].Name() < sources[j].Name()
+	})
+}
+
+// Sources returns every registered multi-product source sorted by name
+func Sources() []Source {
+	return append([]Source(nil), sources...)
+}
+
+// DisplayName returns the display name for a product key, using the registered
+// detector when there is one
+func DisplayName(key string) string {
+	if d, ok := registry[key]; ok {
+		return d.Name()
+	}
+	if key == "" {
+		return key
+	}
+	return strings.ToUpper(key[:1]) + key[1:]
+}
+
+// Lookup returns the detector registered for a product key
+func Lookup(key string) (Detector, bool) {
+	d, ok := registry[key]
+	return d, ok
+}
+
+// MatchImage returns the detector whose Docker images include the given image reference
+// Examples: ruby:3.2-alpine -> ruby, docker.io/library/node:18 -> nodejs
+func MatchImage(image string) (Detector, bool) {
+	repository := imageRepository(image)
+
+	for _, d := range All() {
+		imageDetector, ok := d.(ImageDetector)
+		if !ok {
+			continue
+		}
+		for _, name := range imageDetector.Images() {
+			if repository == name || strings.HasSuffix(repository, "/"+name) {
+				return d, true
+			}
+		}
+	}
+
+	return nil, false
+}
+
+// imageRepository strips the tag and digest from an image reference
+// Examples: ruby:3.2 -> ruby, localhost:5000/app:1.0 -> localhost:5000/app
+func imageRepository(image string) string {
+	if idx := strings.Index(image, "@"); idx >= 0 {
+		image = image[:idx]
+	}
+
+	if idx := strings.LastIndex(image, ":"); idx > strings.LastIndex(image, "/") {
+		image = image[:idx]
+	}
+
+	return image
+}
@@ -0,0 +1,89 @@
+package detectors
+
+import (
+	"testing"
+)
+
+func TestRegistryContainsBuiltinDetectors(t *testing.T) {
+	expected := []string{
+		"angular", "bundler", "dotnet", "go", "java", "kotlin", "laravel", "nextjs",
+		"nodejs", "nuxt", "php", "python", "rails", "react", "ruby", "spring-boot", "symfony", "vue",
+	}
+
+	for _, key := range expected {
+		if _, ok := Lookup(key); !ok {
+			t.Errorf("Expected detector %q to be registered", key)
+		}
+	}
+
+	all := All()
+	for i := 1; i < len(all); i++ {
+		if all[i-1].Key() >= all[i].Key() {
+			t.Errorf("Expected detectors sorted by key, got %q before %q", all[i-1].Key(), all[i].Key())
+		}
+	}
+}
+
+func TestRegisterDuplicatePanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Expected Register to panic on duplicate key")
+		}
+	}()
+
+	Register(FuncDetector{ProductKey: "ruby", DisplayName: "Ruby", DetectFunc: DetectRubyVersion})
+}
+
+func TestMatchImage(t *testing.T) {
+	tests := []struct {
+		image     string
+		expectKey string
+	}{
+		{"ruby:3.2.0-alpine", "ruby"},
+		{"python:3.11-slim", "python"},
+		{"node:18-alpine", "nodejs"},
+		{"golang:1.20.5-alpine", "go"},
+		{"docker.io/library/node:20", "nodejs"},
+		{"eclipse-temurin:17-jdk-alpine", "java"},
+		{"amazoncorretto:21", "java"},
+		{"php:8.2-fpm-alpine", "php"},
+		{"mcr.microsoft.com/dotnet/aspnet:8.0-alpine", "dotnet"},
+		{"ruby@sha256:abcdef", "ruby"},
+		{"mongo:6", ""},
+		{"postgres:15", ""},
+		{"localhost:5000/app:1.0", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.image, func(t *testing.T) {
+			detector, ok := MatchImage(tt.image)
+			if tt.expectKey == "" {
+				if ok {
+					t.Errorf("MatchImage(%q): expected no match, got %q", tt.image, detector.Key())
+				}
+				return
+			}
+			if !ok {
+				t.Fatalf("MatchImage(%q): expected %q, got no match", tt.image, tt.expectKey)
+			}
+			if detector.Key() != tt.expectKey {
+				t.Errorf("MatchImage(%q) = %q, want %q", tt.image, detector.Key(), tt.expectKey)
+			}
+		})
+	}
+}
+
+func TestDisplayName(t *testing.T) {
+	tests := map[string]string{
+		"nodejs":    "Node.js",
+		"dotnet":    ".NET",
+		"terraform": "Terraform",
+		"":          "",
+	}
+
+	for key, expected := range tests {
+		if result := DisplayName(key); result != expected {
+			t.Errorf("DisplayName(%q) = %q, want %q", key, result, expected)
+		}
+	}
+}
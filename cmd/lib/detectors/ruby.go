@@ -1,23 +1,43 @@
 package detectors
 
 import (
-	"os"
+	"io/fs"
 	"strings"
 )
 
+func init() {
+	Register(FuncDetector{
+		ProductKey:    "ruby",
+		DisplayName:   "Ruby",
+		DetectFunc:    DetectRubyVersion,
+		ManifestNames: []string{".ruby-version", "Gemfile.lock"},
+		ImageNames:    []string{"ruby"},
+	})
+}
+
 // DetectRubyVersion checks multiple sources for Ruby version
-func DetectRubyVersion() []Candidate {
+func DetectRubyVersion(fsys fs.FS) []Candidate {
 	var candidates []Candidate
 
 	// Check .ruby-version
-	if data, err := os.ReadFile(".ruby-version"); err == nil {
+	if data, err := fs.ReadFile(fsys, ".ruby-version"); err == nil {
 		if version := strings.TrimSpace(string(data)); version != "" {
 			candidates = append(candidates, Candidate{
 				Value:  version,
 				Source: ".ruby-version",
+				Line:   lineOf(data, version),
 			})
 		}
 	}
 
+	// Check Gemfile.lock RUBY VERSION
+	if lock, err := readGemfileLock(fsys); err == nil && lock.RubyVersion != "" {
+		candidates = append(candidates, Candidate{
+			Value:  lock.RubyVersion,
+			Source: "Gemfile.lock",
+			Line:   lock.RubyLine,
+		})
+	}
+
 	return candidates
 }
@@ -2,6 +2,7 @@ package detectors
 
 import (
 	"os"
+	"path/filepath"
 	"testing"
 )
 
@@ -45,22 +46,15 @@ func TestDetectRubyVersion(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tmpDir := t.TempDir()
-			oldWd, err := os.Getwd()
-			if err != nil {
-				t.Fatalf("Failed to get working directory: %v", err)
-			}
-			defer os.Chdir(oldWd)
-
-			os.Chdir(tmpDir)
 
 			if tt.fileContent != "" || !tt.expectFound {
-				err := os.WriteFile(".ruby-version", []byte(tt.fileContent), 0644)
+				err := os.WriteFile(filepath.Join(tmpDir, ".ruby-version"), []byte(tt.fileContent), 0644)
 				if err != nil {
 					t.Fatalf("Failed to write test file: %v", err)
 				}
 			}
 
-			candidates := DetectRubyVersion()
+			candidates := DetectRubyVersion(os.DirFS(tmpDir))
 
 			if tt.expectFound {
 				if len(candidates) == 0 {
@@ -81,15 +75,8 @@ func TestDetectRubyVersion(t *testing.T) {
 
 func TestDetectRubyVersionNoFile(t *testing.T) {
 	tmpDir := t.TempDir()
-	oldWd, err := os.Getwd()
-	if err != nil {
-		t.Fatalf("Failed to get working directory: %v", err)
-	}
-	defer os.Chdir(oldWd)
-
-	os.Chdir(tmpDir)
 
-	candidates := DetectRubyVersion()
+	candidates := DetectRubyVersion(os.DirFS(tmpDir))
 	if len(candidates) != 0 {
 		t.Errorf("Expected no candidates when .ruby-version doesn't exist, got %v", candidates)
 	}
@@ -0,0 +1,163 @@
+package detectors
+
+import (
+	"errors"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+)
+
+// skippedDirs lists directories that are never scanned for sub-projects
+var skippedDirs = map[string]bool{
+	".git":         true,
+	"node_modules": true,
+	"vendor":       true,
+}
+
+// ManifestPatterns returns the file name patterns of every registered detector
+// and source, plus the Docker files, that mark the root of a project
+func ManifestPatterns() []string {
+	seen := make(map[string]bool)
+	var patterns []string
+
+	add := func(names []string) {
+		for _, name := range names {
+			if !seen[name] {
+				seen[name] = true
+				patterns = append(patterns, name)
+			}
+		}
+	}
+
+	for _, d := range All() {
+		if lister, ok := d.(ManifestLister); ok {
+			add(lister.Manifests())
+		}
+	}
+	for _, s := range Sources() {
+		if lister, ok := s.(ManifestLister); ok {
+			add(lister.Manifests())
+		}
+	}
+	add(dockerManifests)
+
+	sort.Strings(patterns)
+	return patterns
+}
+
+// FindProjectRoots walks fsys and returns every directory containing at least
+// one manifest file, sorted and relative to the root of fsys ("." for the root itself).
+// Directories that cannot be read for lack of permission are skipped.
+func FindProjectRoots(fsys fs.FS) ([]string, error) {
+	patterns := ManifestPatterns()
+	var roots []string
+
+	err := fs.WalkDir(fsys, ".", func(dir string, d fs.DirEntry, err error) error {
+		if err != nil {
+			// Keep scanning past directories that cannot be read
+			if dir != "." && errors.Is(err, fs.ErrPermission) {
+				return fs.SkipDir
+			}
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if dir != "." && skippedDirs[d.Name()] {
+			return fs.SkipDir
+		}
+
+		if hasManifest(fsys, dir, patterns) {
+			roots = append(roots, dir)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(roots)
+	return roots, nil
+}
+
+// hasManifest reports whether dir directly contains a file matching one of the patterns
+func hasManifest(fsys fs.FS, dir string, patterns []string) bool {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return false
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		for _, pattern := range patterns {
+			if matched, _ := path.Match(pattern, entry.Name()); matched {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// projectFS is the file system of a project found in a larger tree. The directories
+// of other projects nested in it are hidden, since those are detected on their own,
+// while the tree stays reachable through Parent for files inherited from above
+type projectFS struct {
+	fs.FS
+	parent fs.FS
+	dir    string
+	hidden map[string]bool
+}
+
+// ProjectFS returns the file system of the project at dir in fsys,
+// hiding the directories of the other projects nested in it
+func ProjectFS(fsys fs.FS, dir string, projects []string) (fs.FS, error) {
+	dir = path.Clean(dir)
+	sub, err := fs.Sub(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	hidden := make(map[string]bool)
+	for _, project := range projects {
+		project = path.Clean(project)
+		switch {
+		case project == dir:
+		case dir == ".":
+			hidden[project] = true
+		case strings.HasPrefix(project, dir+"/"):
+			hidden[strings.TrimPrefix(project, dir+"/")] = true
+		}
+	}
+
+	return projectFS{FS: sub, parent: fsys, dir: dir, hidden: hidden}, nil
+}
+
+// ReadDir lists the directory name, leaving out nested projects
+func (p projectFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	entries, err := fs.ReadDir(p.FS, name)
+	if err != nil {
+		return nil, err
+	}
+
+	visible := entries[:0]
+	for _, entry := range entries {
+		if !entry.IsDir() || !p.hidden[path.Join(name, entry.Name())] {
+			visible = append(visible, entry)
+		}
+	}
+	return visible, nil
+}
+
+// Parent returns the file system the project was found in and the project's path there
+func (p projectFS) Parent() (fs.FS, string) {
+	return p.parent, p.dir
+}
+
+// parentFS is implemented by file systems that give access to the directories above their root
+type parentFS interface {
+	Parent() (fs.FS, string)
+}
@@ -0,0 +1,164 @@
+package detectors
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+func TestFindProjectRoots(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	files := []string{
+		"package.json",
+		"README.md",
+		"apps/web/package.json",
+		"apps/web/node_modules/react/package.json",
+		"apps/docs/README.md",
+		"services/api/go.mod",
+		"services/billing/Gemfile",
+		"services/billing/vendor/bundle/Gemfile",
+		"services/worker/Dockerfile.prod",
+		"services/legacy/src/Legacy.csproj",
+		"tools/.tool-versions",
+		".git/config",
+	}
+	for _, file := range files {
+		path := filepath.Join(tmpDir, file)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create directory for %s: %v", file, err)
+		}
+		if err := os.WriteFile(path, []byte(""), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", file, err)
+		}
+	}
+
+	roots, err := FindProjectRoots(os.DirFS(tmpDir))
+	if err != nil {
+		t.Fatalf("FindProjectRoots() failed: %v", err)
+	}
+
+	expected := []string{
+		".",
+		"apps/web",
+		"services/api",
+		"services/billing",
+		"services/legacy/src",
+		"services/worker",
+		"tools",
+	}
+	if !reflect.DeepEqual(roots, expected) {
+		t.Errorf("FindProjectRoots() = %v, want %v", roots, expected)
+	}
+}
+
+func TestFindProjectRootsMissingDir(t *testing.T) {
+	if _, err := FindProjectRoots(os.DirFS(filepath.Join(t.TempDir(), "missing"))); err == nil {
+		t.Errorf("Expected error for missing directory")
+	}
+}
+
+func TestFindProjectRootsMapFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"web/package.json":    {Data: []byte("{}")},
+		"api/go.mod":          {Data: []byte("module api\n")},
+		"api/vendor/x/go.mod": {Data: []byte("module x\n")},
+		"docs/README.md":      {Data: []byte("")},
+	}
+
+	roots, err := FindProjectRoots(fsys)
+	if err != nil {
+		t.Fatalf("FindProjectRoots() failed: %v", err)
+	}
+
+	expected := []string{"api", "web"}
+	if !reflect.DeepEqual(roots, expected) {
+		t.Errorf("FindProjectRoots() = %v, want %v", roots, expected)
+	}
+}
+
+// deniedFS fails to list the directories in denied with a permission error
+type deniedFS struct {
+	fstest.MapFS
+	denied map[string]bool
+}
+
+func (d deniedFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	if d.denied[name] {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrPermission}
+	}
+	return d.MapFS.ReadDir(name)
+}
+
+func TestFindProjectRootsPermissionDenied(t *testing.T) {
+	fsys := deniedFS{
+		MapFS: fstest.MapFS{
+			"web/package.json":    {Data: []byte("{}")},
+			"private/go.mod":      {Data: []byte("module private\n")},
+			"private/sub/Gemfile": {Data: []byte("")},
+			"zz/api/go.mod":       {Data: []byte("module api\n")},
+		},
+		denied: map[string]bool{"private": true},
+	}
+
+	roots, err := FindProjectRoots(fsys)
+	if err != nil {
+		t.Fatalf("FindProjectRoots() failed: %v", err)
+	}
+
+	expected := []string{"web", "zz/api"}
+	if !reflect.DeepEqual(roots, expected) {
+		t.Errorf("FindProjectRoots() = %v, want %v", roots, expected)
+	}
+}
+
+func TestDetectDotnetNestedProjects(t *testing.T) {
+	fsys := fstest.MapFS{
+		"App.sln":                         {Data: []byte("")},
+		"global.json":                     {Data: []byte(`{"sdk": {"version": "8.0.100"}}`)},
+		"Directory.Build.props":           {Data: []byte("<Project><PropertyGroup><TargetFramework>net6.0</TargetFramework></PropertyGroup></Project>")},
+		"services/api/Api.csproj":         {Data: []byte("<Project><PropertyGroup><TargetFramework>net8.0</TargetFramework></PropertyGroup></Project>")},
+		"services/worker/Worker.csproj":   {Data: []byte("<Project></Project>")},
+		"services/worker/vendor/X.csproj": {Data: []byte("<Project><PropertyGroup><TargetFramework>net5.0</TargetFramework></PropertyGroup></Project>")},
+	}
+
+	roots, err := FindProjectRoots(fsys)
+	if err != nil {
+		t.Fatalf("FindProjectRoots() failed: %v", err)
+	}
+
+	expectedRoots := []string{".", "services/api", "services/worker"}
+	if !reflect.DeepEqual(roots, expectedRoots) {
+		t.Fatalf("FindProjectRoots() = %v, want %v", roots, expectedRoots)
+	}
+
+	// Each project file is detected once, by the project it belongs to,
+	// and the worker inherits the target framework of the root's Directory.Build.props
+	expected := map[string][]Candidate{
+		".":               {{Value: "8.0.100", Source: "global.json", Line: 1}},
+		"services/api":    {{Value: "8.0", Source: "Api.csproj", Line: 1}},
+		"services/worker": {{Value: "6.0", Source: "../../Directory.Build.props", Line: 1}},
+	}
+	for _, root := range roots {
+		sub, err := ProjectFS(fsys, root, roots)
+		if err != nil {
+			t.Fatalf("ProjectFS(%s) failed: %v", root, err)
+		}
+
+		if candidates := DetectDotnet(sub); !reflect.DeepEqual(candidates, expected[root]) {
+			t.Errorf("DetectDotnet(%s) = %v, want %v", root, candidates, expected[root])
+		}
+	}
+
+	// Without a recursive scan, the root detects every project below it
+	var values []string
+	for _, candidate := range DetectDotnet(fsys) {
+		values = append(values, candidate.Value)
+	}
+	if expectedValues := []string{"8.0.100", "8.0", "6.0"}; !reflect.DeepEqual(values, expectedValues) {
+		t.Errorf("DetectDotnet(.) = %v, want %v", values, expectedValues)
+	}
+}
@@ -0,0 +1,70 @@
+package detectors
+
+import (
+	"encoding/xml"
+	"io/fs"
+	"regexp"
+	"strings"
+)
+
+func init() {
+	Register(FuncDetector{
+		ProductKey:    "spring-boot",
+		DisplayName:   "Spring Boot",
+		DetectFunc:    DetectSpringBoot,
+		ManifestNames: []string{"pom.xml", "build.gradle", "build.gradle.kts"},
+	})
+}
+
+// pomParent holds the <parent> section of a Maven pom.xml
+type pomParent struct {
+	Parent struct {
+		GroupID    string `xml:"groupId"`
+		ArtifactID string `xml:"artifactId"`
+		Version    string `xml:"version"`
+	} `xml:"parent"`
+}
+
+// DetectSpringBoot checks Maven and Gradle build files for Spring Boot
+func DetectSpringBoot(fsys fs.FS) []Candidate {
+	var candidates []Candidate
+
+	// Check pom.xml parent or spring-boot.version property
+	if data, err := fs.ReadFile(fsys, "pom.xml"); err == nil {
+		var pom pomParent
+		if err := xml.Unmarshal(data, &pom); err == nil &&
+			pom.Parent.GroupID == "org.springframework.boot" &&
+			strings.TrimSpace(pom.Parent.Version) != "" {
+			candidates = append(candidates, Candidate{
+				Value:  strings.TrimSpace(pom.Parent.Version),
+				Source: "pom.xml",
+				Line:   lineOf(data, "<version>", strings.TrimSpace(pom.Parent.Version)),
+			})
+		} else if version := findPomProperty(string(data), "spring-boot.version"); version != "" {
+			candidates = append(candidates, Candidate{
+				Value:  version,
+				Source: "pom.xml",
+				Line:   lineOf(data, "<spring-boot.version>"),
+			})
+		}
+	}
+
+	// Check Gradle plugin declaration
+	re := regexp.MustCompile(`id\s*\(?\s*['"]org\.springframework\.boot['"]\s*\)?\s+version\s+['"]([^'"]+)['"]`)
+	for _, file := range gradleBuildFiles {
+		data, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			continue
+		}
+
+		if matches := re.FindStringSubmatch(string(data)); len(matches) > 1 {
+			candidates = append(candidates, Candidate{
+				Value:  matches[1],
+				Source: file,
+				Line:   lineOf(data, matches[0]),
+			})
+		}
+	}
+
+	return candidates
+}
@@ -0,0 +1,88 @@
+package detectors
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDetectSpringBoot(t *testing.T) {
+	tests := []struct {
+		name          string
+		files         map[string]string
+		expectValues  []string
+		expectSources []string
+	}{
+		{
+			name: "spring boot parent in pom.xml",
+			files: map[string]string{"pom.xml": `<?xml version="1.0" encoding="UTF-8"?>
+<project xmlns="http://maven.apache.org/POM/4.0.0">
+  <parent>
+    <groupId>org.springframework.boot</groupId>
+    <artifactId>spring-boot-starter-parent</artifactId>
+    <version>3.2.1</version>
+    <relativePath/>
+  </parent>
+</project>`},
+			expectValues:  []string{"3.2.1"},
+			expectSources: []string{"pom.xml"},
+		},
+		{
+			name: "spring-boot.version property in pom.xml",
+			files: map[string]string{"pom.xml": `<project>
+  <parent>
+    <groupId>com.example</groupId>
+    <artifactId>company-parent</artifactId>
+    <version>1.0.0</version>
+  </parent>
+  <properties>
+    <spring-boot.version>2.7.18</spring-boot.version>
+  </properties>
+</project>`},
+			expectValues:  []string{"2.7.18"},
+			expectSources: []string{"pom.xml"},
+		},
+		{
+			name:          "gradle groovy plugin",
+			files:         map[string]string{"build.gradle": "plugins {\n  id 'org.springframework.boot' version '3.1.5'\n}\n"},
+			expectValues:  []string{"3.1.5"},
+			expectSources: []string{"build.gradle"},
+		},
+		{
+			name:          "gradle kotlin plugin",
+			files:         map[string]string{"build.gradle.kts": "plugins {\n  id(\"org.springframework.boot\") version \"3.2.0\"\n}\n"},
+			expectValues:  []string{"3.2.0"},
+			expectSources: []string{"build.gradle.kts"},
+		},
+		{
+			name:  "non spring parent",
+			files: map[string]string{"pom.xml": "<project><parent><groupId>com.example</groupId><version>1.0</version></parent></project>"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+
+			for file, content := range tt.files {
+				if err := os.WriteFile(filepath.Join(tmpDir, file), []byte(content), 0644); err != nil {
+					t.Fatalf("Failed to write %s: %v", file, err)
+				}
+			}
+
+			candidates := DetectSpringBoot(os.DirFS(tmpDir))
+
+			if len(candidates) != len(tt.expectValues) {
+				t.Fatalf("Expected %d candidates, got %d: %v", len(tt.expectValues), len(candidates), candidates)
+			}
+			for i, expected := range tt.expectValues {
+				if candidates[i].Value != expected {
+					t.Errorf("Expected value %q at index %d, got %q", expected, i, candidates[i].Value)
+				}
+				if candidates[i].Source != tt.expectSources[i] {
+					t.Errorf("Expected source %q at index %d, got %q", tt.expectSources[i], i, candidates[i].Source)
+				}
+			}
+		})
+	}
+}
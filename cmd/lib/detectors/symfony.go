@@ -0,0 +1,17 @@
+package detectors
+
+import "io/fs"
+
+func init() {
+	Register(FuncDetector{
+		ProductKey:    "symfony",
+		DisplayName:   "Symfony",
+		DetectFunc:    DetectSymfony,
+		ManifestNames: []string{"composer.json", "composer.lock"},
+	})
+}
+
+// DetectSymfony checks composer.lock and composer.json for Symfony
+func DetectSymfony(fsys fs.FS) []Candidate {
+	return detectComposerPackage(fsys, "symfony/framework-bundle")
+}
@@ -0,0 +1,129 @@
+package detectors
+
+import (
+	"bufio"
+	"io/fs"
+	"regexp"
+	"strings"
+
+	"github.com/stacktodate/stacktodate-cli/cmd/lib/versions"
+)
+
+func init() {
+	RegisterSource(FuncSource{
+		SourceName:    ".tool-versions",
+		DetectFunc:    DetectToolVersions,
+		ManifestNames: []string{".tool-versions", "mise.toml", ".mise.toml"},
+	})
+}
+
+// toolProductKeys maps asdf/mise tool names to catalog product keys
+var toolProductKeys = map[string]string{
+	"ruby":        "ruby",
+	"nodejs":      "nodejs",
+	"node":        "nodejs",
+	"python":      "python",
+	"golang":      "go",
+	"go":          "go",
+	"java":        "java",
+	"kotlin":      "kotlin",
+	"php":         "php",
+	"dotnet":      "dotnet",
+	"dotnet-core": "dotnet",
+	"erlang":      "erlang",
+	"elixir":      "elixir",
+	"terraform":   "terraform",
+	"rust":        "rust",
+	"deno":        "deno",
+	"bun":         "bun",
+	"postgres":    "postgresql",
+	"postgresql":  "postgresql",
+	"redis":       "redis",
+	"kubectl":     "kubernetes",
+}
+
+// miseConfigFiles lists the mise configuration files checked, in priority order
+var miseConfigFiles = []string{"mise.toml", ".mise.toml"}
+
+// DetectToolVersions checks .tool-versions and mise.toml for every pinned runtime
+func DetectToolVersions(fsys fs.FS) map[string][]Candidate {
+	candidates := make(map[string][]Candidate)
+
+	// Check .tool-versions (e.g. "nodejs 20.11.0 18.19.0")
+	if data, err := fs.ReadFile(fsys, ".tool-versions"); err == nil {
+		lineNumber := 0
+		scanner := bufio.NewScanner(strings.NewReader(string(data)))
+		for scanner.Scan() {
+			lineNumber++
+			line := scanner.Text()
+			if idx := strings.Index(line, "#"); idx >= 0 {
+				line = line[:idx]
+			}
+
+			fields := strings.Fields(line)
+			if len(fields) < 2 {
+				continue
+			}
+
+			addToolCandidate(candidates, fields[0], fields[1], ".tool-versions", lineNumber)
+		}
+	}
+
+	// Check the [tools] table of mise.toml (e.g. node = "20", python = ["3.11", "3.10"])
+	keyRe := regexp.MustCompile(`^\s*["']?([\w.-]+)["']?\s*=\s*(.+)$`)
+	valueRe := regexp.MustCompile(`["']([^"']+)["']`)
+	for _, file := range miseConfigFiles {
+		data, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			continue
+		}
+
+		inTools := false
+		lineNumber := 0
+		scanner := bufio.NewScanner(strings.NewReader(string(data)))
+		for scanner.Scan() {
+			lineNumber++
+			line := strings.TrimSpace(scanner.Text())
+			if strings.HasPrefix(line, "[") {
+				inTools = line == "[tools]"
+				continue
+			}
+			if !inTools {
+				continue
+			}
+
+			matches := keyRe.FindStringSubmatch(line)
+			if len(matches) < 3 {
+				continue
+			}
+			if value := valueRe.FindStringSubmatch(matches[2]); len(value) > 1 {
+				addToolCandidate(candidates, matches[1], value[1], file, lineNumber)
+			}
+		}
+		break
+	}
+
+	return candidates
+}
+
+// addToolCandidate records a tool version under its catalog product key,
+// ignoring unknown tools and non-version values such as "system"
+func addToolCandidate(candidates map[string][]Candidate, tool, version, source string, line int) {
+	product, ok := toolProductKeys[tool]
+	if !ok || version == "" || version == "system" {
+		return
+	}
+
+	// Java versions carry a distribution prefix, e.g. temurin-17.0.9+9
+	if product == "java" {
+		if v, ok := versions.Find(version); ok {
+			version = normalizeJavaVersion(v.String())
+		}
+	}
+
+	candidates[product] = append(candidates[product], Candidate{
+		Value:  version,
+		Source: source,
+		Line:   line,
+	})
+}
@@ -0,0 +1,92 @@
+package detectors
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestDetectToolVersions(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    map[string]string
+		expected map[string][]Candidate
+	}{
+		{
+			name: ".tool-versions with several tools",
+			files: map[string]string{".tool-versions": `# runtimes
+ruby 3.2.2
+nodejs 20.11.0 18.19.0
+golang 1.22.1
+java temurin-17.0.9+9
+erlang 26.2.1
+elixir 1.16.1-otp-26
+terraform 1.7.4 # infra
+shellcheck 0.9.0
+python system
+`},
+			expected: map[string][]Candidate{
+				"ruby":      {{Value: "3.2.2", Source: ".tool-versions", Line: 2}},
+				"nodejs":    {{Value: "20.11.0", Source: ".tool-versions", Line: 3}},
+				"go":        {{Value: "1.22.1", Source: ".tool-versions", Line: 4}},
+				"java":      {{Value: "17.0.9", Source: ".tool-versions", Line: 5}},
+				"erlang":    {{Value: "26.2.1", Source: ".tool-versions", Line: 6}},
+				"elixir":    {{Value: "1.16.1-otp-26", Source: ".tool-versions", Line: 7}},
+				"terraform": {{Value: "1.7.4", Source: ".tool-versions", Line: 8}},
+			},
+		},
+		{
+			name: "mise.toml tools table",
+			files: map[string]string{"mise.toml": `[env]
+NODE_ENV = "production"
+
+[tools]
+node = "20"
+python = ["3.11", "3.10"]
+java = { version = "corretto-21", os = ["linux"] }
+"npm:prettier" = "3"
+`},
+			expected: map[string][]Candidate{
+				"nodejs": {{Value: "20", Source: "mise.toml", Line: 5}},
+				"python": {{Value: "3.11", Source: "mise.toml", Line: 6}},
+				"java":   {{Value: "21", Source: "mise.toml", Line: 7}},
+			},
+		},
+		{
+			name: "both files",
+			files: map[string]string{
+				".tool-versions": "ruby 3.3.0\n",
+				".mise.toml":     "[tools]\nruby = \"3.3\"\n",
+			},
+			expected: map[string][]Candidate{
+				"ruby": {
+					{Value: "3.3.0", Source: ".tool-versions", Line: 1},
+					{Value: "3.3", Source: ".mise.toml", Line: 2},
+				},
+			},
+		},
+		{
+			name:     "no files",
+			expected: map[string][]Candidate{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+
+			for file, content := range tt.files {
+				if err := os.WriteFile(filepath.Join(tmpDir, file), []byte(content), 0644); err != nil {
+					t.Fatalf("Failed to write %s: %v", file, err)
+				}
+			}
+
+			candidates := DetectToolVersions(os.DirFS(tmpDir))
+
+			if !reflect.DeepEqual(candidates, tt.expected) {
+				t.Errorf("DetectToolVersions(os.DirFS(tmpDir)) = %v, want %v", candidates, tt.expected)
+			}
+		})
+	}
+}
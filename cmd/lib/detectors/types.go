@@ -1,7 +1,75 @@
 package detectors
 
+import (
+	"bytes"
+	"io/fs"
+	"strings"
+
+	"github.com/stacktodate/stacktodate-cli/cmd/lib/versions"
+)
+
 // Candidate represents a detected value with its source
 type Candidate struct {
-	Value  string // The detected version/value
-	Source string // Where it was found (e.g., ".ruby-version", "Gemfile", "Dockerfile")
+	Value  string          // The detected version/value
+	Source string          // Where it was found (e.g., ".ruby-version", "Gemfile", "Dockerfile")
+	Line   int             // 1-based line in Source the value was read from, 0 if unknown
+	Syntax versions.Syntax // How Value is written, e.g. versions.NPM for package.json engines
+
+	// Set once the version has been resolved against the release cycles of its product
+	Spec     string // The value as written in Source, e.g. ">=18 <21"
+	MinCycle string // Lowest release cycle the spec allows
+	MaxCycle string // Highest release cycle the spec allows
+}
+
+// lineAt returns the 1-based line number of a byte offset in data
+func lineAt(data []byte, offset int) int {
+	return bytes.Count(data[:offset], []byte("\n")) + 1
+}
+
+// lineOf returns the 1-based number of the first line in data containing
+// every one of the given substrings, or 0 when no line does
+func lineOf(data []byte, substrings ...string) int {
+	for i, line := range strings.Split(string(data), "\n") {
+		found := true
+		for _, substring := range substrings {
+			if !strings.Contains(line, substring) {
+				found = false
+				break
+			}
+		}
+		if found {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// fileLineOf is lineOf for a file of fsys
+func fileLineOf(fsys fs.FS, file string, substrings ...string) int {
+	data, err := fs.ReadFile(fsys, file)
+	if err != nil {
+		return 0
+	}
+	return lineOf(data, substrings...)
+}
+
+// lineOfAfter returns the first line of a file of fsys containing value at or after
+// the first line containing anchor, e.g. the "version" line of a package in a lockfile
+func lineOfAfter(fsys fs.FS, file, anchor, value string) int {
+	data, err := fs.ReadFile(fsys, file)
+	if err != nil {
+		return 0
+	}
+
+	start := lineOf(data, anchor)
+	if start == 0 {
+		return 0
+	}
+	lines := strings.Split(string(data), "\n")
+	for i := start - 1; i < len(lines); i++ {
+		if strings.Contains(lines[i], value) {
+			return i + 1
+		}
+	}
+	return 0
 }
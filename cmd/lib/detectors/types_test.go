@@ -0,0 +1,78 @@
+package detectors
+
+import (
+	"testing"
+	"testing/fstest"
+)
+
+func TestLineOf(t *testing.T) {
+	data := []byte("module app\n\ngo 1.22\n\ntoolchain go1.22.3\n")
+
+	tests := []struct {
+		substrings []string
+		expected   int
+	}{
+		{[]string{"module"}, 1},
+		{[]string{"go 1.22"}, 3},
+		{[]string{"go", "1.22.3"}, 5},
+		{[]string{"missing"}, 0},
+	}
+
+	for _, tt := range tests {
+		if line := lineOf(data, tt.substrings...); line != tt.expected {
+			t.Errorf("lineOf(%q) = %d, want %d", tt.substrings, line, tt.expected)
+		}
+	}
+}
+
+func TestCandidateLines(t *testing.T) {
+	fsys := fstest.MapFS{
+		"go.mod":     {Data: []byte("module app\n\ngo 1.22\n")},
+		"Dockerfile": {Data: []byte("FROM golang:1.22 AS build\nRUN make\n\nFROM ruby:3.3-slim\n")},
+		"Gemfile.lock": {Data: []byte(`GEM
+  specs:
+    actionpack (7.1.2)
+    rails (7.1.2)
+      actionpack (= 7.1.2)
+
+RUBY VERSION
+   ruby 3.3.0p0
+
+BUNDLED WITH
+   2.5.3
+`)},
+		"composer.lock": {Data: []byte(`{
+  "packages": [
+    {
+      "name": "laravel/framework",
+      "version": "v10.48.4"
+    }
+  ]
+}`)},
+	}
+
+	tests := []struct {
+		name       string
+		candidates []Candidate
+		expected   []int
+	}{
+		{"go.mod", DetectGo(fsys), []int{3}},
+		{"Dockerfile", DetectDocker(fsys), []int{1, 4}},
+		{"rails", DetectRails(fsys), []int{4}},
+		{"ruby", DetectRubyVersion(fsys), []int{8}},
+		{"bundler", DetectBundler(fsys), []int{11}},
+		{"laravel", detectComposerPackage(fsys, "laravel/framework"), []int{5}},
+	}
+
+	for _, tt := range tests {
+		if len(tt.candidates) != len(tt.expected) {
+			t.Errorf("%s: expected %d candidates, got %d: %v", tt.name, len(tt.expected), len(tt.candidates), tt.candidates)
+			continue
+		}
+		for i, line := range tt.expected {
+			if tt.candidates[i].Line != line {
+				t.Errorf("%s: expected line %d at index %d, got %d", tt.name, line, i, tt.candidates[i].Line)
+			}
+		}
+	}
+}
@@ -0,0 +1,328 @@
+package gitfs
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxSymlinks limits how many symbolic links are followed while resolving a path
+const maxSymlinks = 40
+
+// Tree entry modes
+const (
+	modeTree       = 0o040000
+	modeFile       = 0o100644
+	modeExecutable = 0o100755
+	modeSymlink    = 0o120000
+	modeGitlink    = 0o160000
+)
+
+// treeEntry is one entry of a git tree object
+type treeEntry struct {
+	name string
+	mode uint32
+	hash Hash
+}
+
+func (e treeEntry) isDir() bool {
+	return e.mode == modeTree || e.mode == modeGitlink
+}
+
+// fileMode maps a git mode to a read-only fs.FileMode
+func (e treeEntry) fileMode() fs.FileMode {
+	switch e.mode {
+	case modeTree, modeGitlink:
+		return fs.ModeDir | 0o555
+	case modeSymlink:
+		return fs.ModeSymlink | 0o777
+	case modeExecutable:
+		return 0o555
+	}
+	return 0o444
+}
+
+// readTree parses a tree object, caching the result
+func (r *Repository) readTree(hash Hash) ([]treeEntry, error) {
+	if entries, ok := r.trees[hash]; ok {
+		return entries, nil
+	}
+
+	objType, data, err := r.readObject(hash)
+	if err != nil {
+		return nil, err
+	}
+	if objType != "tree" {
+		return nil, fmt.Errorf("object %s is a %s, not a tree", hash, objType)
+	}
+
+	// Each entry is "<octal mode> <name>\x00<20 byte hash>"
+	var entries []treeEntry
+	for len(data) > 0 {
+		header, rest, ok := bytes.Cut(data, []byte{0})
+		if !ok || len(rest) < 20 {
+			return nil, fmt.Errorf("invalid tree %s", hash)
+		}
+		mode, name, ok := bytes.Cut(header, []byte(" "))
+		if !ok {
+			return nil, fmt.Errorf("invalid tree %s", hash)
+		}
+		parsedMode, err := strconv.ParseUint(string(mode), 8, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid mode in tree %s", hash)
+		}
+
+		entry := treeEntry{name: string(name), mode: uint32(parsedMode)}
+		copy(entry.hash[:], rest[:20])
+		entries = append(entries, entry)
+		data = rest[20:]
+	}
+
+	// Git orders directories as if their name ended in a slash
+	sort.Slice(entries, func(i, j int) bool { return entries[i].name < entries[j].name })
+
+	r.trees[hash] = entries
+	return entries, nil
+}
+
+// treeFS exposes a git tree as an fs.FS
+type treeFS struct {
+	repo *Repository
+	root Hash
+}
+
+// Open opens the named file or directory, following symbolic links inside the tree
+func (t *treeFS) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+
+	entry, err := t.lookup(name, 0)
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+
+	if entry.isDir() {
+		entries, err := t.readDir(entry)
+		if err != nil {
+			return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+		}
+		return &dirFile{info: fileInfo{name: path.Base(name), mode: entry.fileMode()}, entries: entries}, nil
+	}
+
+	data, err := t.readBlob(entry)
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+	info := fileInfo{name: path.Base(name), mode: entry.fileMode(), size: int64(len(data))}
+	return &blobFile{info: info, reader: bytes.NewReader(data)}, nil
+}
+
+// ReadFile reads the named blob without going through Open
+func (t *treeFS) ReadFile(name string) ([]byte, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "readfile", Path: name, Err: fs.ErrInvalid}
+	}
+
+	entry, err := t.lookup(name, 0)
+	if err == nil && entry.isDir() {
+		err = errors.New("is a directory")
+	}
+	if err != nil {
+		return nil, &fs.PathError{Op: "readfile", Path: name, Err: err}
+	}
+
+	data, err := t.readBlob(entry)
+	if err != nil {
+		return nil, &fs.PathError{Op: "readfile", Path: name, Err: err}
+	}
+	return data, nil
+}
+
+// ReadDir lists the named directory sorted by name
+func (t *treeFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrInvalid}
+	}
+
+	entry, err := t.lookup(name, 0)
+	if err == nil && !entry.isDir() {
+		err = errors.New("not a directory")
+	}
+	if err != nil {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: err}
+	}
+
+	entries, err := t.readDir(entry)
+	if err != nil {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: err}
+	}
+	return entries, nil
+}
+
+// lookup walks the tree to the entry for name
+func (t *treeFS) lookup(name string, depth int) (treeEntry, error) {
+	entry := treeEntry{name: ".", mode: modeTree, hash: t.root}
+	if name == "." {
+		return entry, nil
+	}
+
+	parts := strings.Split(name, "/")
+	for i, part := range parts {
+		if entry.mode != modeTree {
+			return treeEntry{}, fs.ErrNotExist
+		}
+
+		entries, err := t.repo.readTree(entry.hash)
+		if err != nil {
+			return treeEntry{}, err
+		}
+		j := sort.Search(len(entries), func(j int) bool { return entries[j].name >= part })
+		if j == len(entries) || entries[j].name != part {
+			return treeEntry{}, fs.ErrNotExist
+		}
+		entry = entries[j]
+
+		if entry.mode == modeSymlink {
+			// Follow relative links that stay inside the tree, like os.DirFS would
+			if depth >= maxSymlinks {
+				return treeEntry{}, errors.New("too many levels of symbolic links")
+			}
+			target, err := t.readBlob(entry)
+			if err != nil {
+				return treeEntry{}, err
+			}
+			resolved := path.Join(path.Dir(strings.Join(parts[:i+1], "/")), string(target))
+			if path.IsAbs(string(target)) || !fs.ValidPath(resolved) {
+				return treeEntry{}, fs.ErrNotExist
+			}
+			return t.lookup(path.Join(append([]string{resolved}, parts[i+1:]...)...), depth+1)
+		}
+	}
+
+	return entry, nil
+}
+
+// readDir returns the directory entries of a tree; submodules appear empty
+func (t *treeFS) readDir(entry treeEntry) ([]fs.DirEntry, error) {
+	if entry.mode == modeGitlink {
+		return []fs.DirEntry{}, nil
+	}
+
+	entries, err := t.repo.readTree(entry.hash)
+	if err != nil {
+		return nil, err
+	}
+
+	dirEntries := make([]fs.DirEntry, len(entries))
+	for i, child := range entries {
+		dirEntries[i] = dirEntry{fsys: t, entry: child}
+	}
+	return dirEntries, nil
+}
+
+// readBlob returns the content of a blob entry
+func (t *treeFS) readBlob(entry treeEntry) ([]byte, error) {
+	objType, data, err := t.repo.readObject(entry.hash)
+	if err != nil {
+		return nil, err
+	}
+	if objType != "blob" {
+		return nil, fmt.Errorf("object %s is a %s, not a blob", entry.hash, objType)
+	}
+	return data, nil
+}
+
+// fileInfo describes a file or directory of a tree; git keeps no modification times
+type fileInfo struct {
+	name string
+	mode fs.FileMode
+	size int64
+}
+
+func (i fileInfo) Name() string       { return i.name }
+func (i fileInfo) Size() int64        { return i.size }
+func (i fileInfo) Mode() fs.FileMode  { return i.mode }
+func (i fileInfo) ModTime() time.Time { return time.Time{} }
+func (i fileInfo) IsDir() bool        { return i.mode.IsDir() }
+func (i fileInfo) Sys() any           { return nil }
+
+// dirEntry is a lazily stat-ed tree entry
+type dirEntry struct {
+	fsys  *treeFS
+	entry treeEntry
+}
+
+func (d dirEntry) Name() string      { return d.entry.name }
+func (d dirEntry) IsDir() bool       { return d.entry.isDir() }
+func (d dirEntry) Type() fs.FileMode { return d.entry.fileMode().Type() }
+
+// Info returns the entry's own information without following symbolic links
+func (d dirEntry) Info() (fs.FileInfo, error) {
+	info := fileInfo{name: d.entry.name, mode: d.entry.fileMode()}
+	if !d.entry.isDir() {
+		data, err := d.fsys.readBlob(d.entry)
+		if err != nil {
+			return nil, err
+		}
+		info.size = int64(len(data))
+	}
+	return info, nil
+}
+
+// blobFile is an open file of a tree
+type blobFile struct {
+	info   fileInfo
+	reader *bytes.Reader
+}
+
+func (f *blobFile) Stat() (fs.FileInfo, error) { return f.info, nil }
+func (f *blobFile) Read(b []byte) (int, error) { return f.reader.Read(b) }
+func (f *blobFile) Close() error               { return nil }
+
+// Seek and ReadAt let callers such as http.FileServer treat blobs like regular files
+func (f *blobFile) Seek(offset int64, whence int) (int64, error) {
+	return f.reader.Seek(offset, whence)
+}
+
+func (f *blobFile) ReadAt(b []byte, offset int64) (int, error) {
+	return f.reader.ReadAt(b, offset)
+}
+
+// dirFile is an open directory of a tree
+type dirFile struct {
+	info    fileInfo
+	entries []fs.DirEntry
+	offset  int
+}
+
+func (d *dirFile) Stat() (fs.FileInfo, error) { return d.info, nil }
+func (d *dirFile) Close() error               { return nil }
+
+func (d *dirFile) Read([]byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: d.info.name, Err: errors.New("is a directory")}
+}
+
+// ReadDir returns the next n entries, or all remaining ones when n <= 0
+func (d *dirFile) ReadDir(n int) ([]fs.DirEntry, error) {
+	remaining := d.entries[d.offset:]
+	if n <= 0 {
+		d.offset = len(d.entries)
+		return remaining, nil
+	}
+	if len(remaining) == 0 {
+		return nil, io.EOF
+	}
+	if n > len(remaining) {
+		n = len(remaining)
+	}
+	d.offset += n
+	return remaining[:n], nil
+}
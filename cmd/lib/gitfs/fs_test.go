@@ -0,0 +1,146 @@
+package gitfs
+
+import (
+	"bytes"
+	"compress/zlib"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestFS(t *testing.T) {
+	for _, packed := range []bool{false, true} {
+		dir := newTestRepo(t, packed)
+		repo, err := Open(dir)
+		if err != nil {
+			t.Fatalf("Open() failed: %v", err)
+		}
+
+		fsys, err := repo.FS("HEAD")
+		if err != nil {
+			t.Fatalf("FS(HEAD) failed: %v", err)
+		}
+		if err := fstest.TestFS(fsys, "go.mod", "web/.nvmrc", "web/package.json"); err != nil {
+			t.Errorf("TestFS failed (packed=%v): %v", packed, err)
+		}
+
+		// Files are read from the revision, not from the working tree
+		if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("go 1.99\n"), 0644); err != nil {
+			t.Fatalf("Failed to write go.mod: %v", err)
+		}
+
+		tests := map[string]string{
+			"HEAD":    "go 1.22",
+			"v1.0.0":  "go 1.21",
+			"feature": "go 1.21",
+		}
+		for rev, expected := range tests {
+			fsys, err := repo.FS(rev)
+			if err != nil {
+				t.Fatalf("FS(%q) failed: %v", rev, err)
+			}
+			data, err := fs.ReadFile(fsys, "go.mod")
+			if err != nil {
+				t.Fatalf("ReadFile(go.mod) at %s failed: %v", rev, err)
+			}
+			if !strings.Contains(string(data), expected) {
+				t.Errorf("go.mod at %s = %q, want it to contain %q (packed=%v)", rev, data, expected, packed)
+			}
+		}
+	}
+}
+
+func TestFSMissingFile(t *testing.T) {
+	dir := newTestRepo(t, false)
+	repo, err := Open(dir)
+	if err != nil {
+		t.Fatalf("Open() failed: %v", err)
+	}
+	fsys, err := repo.FS("HEAD")
+	if err != nil {
+		t.Fatalf("FS(HEAD) failed: %v", err)
+	}
+
+	for _, name := range []string{"Gemfile", "web/missing", "go.mod/child", "../go.mod"} {
+		if _, err := fs.ReadFile(fsys, name); err == nil {
+			t.Errorf("Expected error reading %q", name)
+		}
+	}
+	if _, err := fs.ReadFile(fsys, "Gemfile"); !os.IsNotExist(err) {
+		t.Errorf("Expected not exist error for Gemfile, got %v", err)
+	}
+}
+
+func TestFSSymlinks(t *testing.T) {
+	dir := newTestRepo(t, false)
+	if err := os.Symlink("web/.nvmrc", filepath.Join(dir, ".nvmrc")); err != nil {
+		t.Skipf("symlinks not supported: %v", err)
+	}
+	if err := os.Symlink("web", filepath.Join(dir, "frontend")); err != nil {
+		t.Fatalf("Failed to create symlink: %v", err)
+	}
+	runGit(t, dir, "add", "-A")
+	runGit(t, dir, "commit", "-q", "-m", "links")
+
+	repo, err := Open(dir)
+	if err != nil {
+		t.Fatalf("Open() failed: %v", err)
+	}
+	fsys, err := repo.FS("HEAD")
+	if err != nil {
+		t.Fatalf("FS(HEAD) failed: %v", err)
+	}
+
+	for _, name := range []string{".nvmrc", "frontend/.nvmrc"} {
+		data, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			t.Fatalf("ReadFile(%q) failed: %v", name, err)
+		}
+		if strings.TrimSpace(string(data)) != "20" {
+			t.Errorf("ReadFile(%q) = %q, want %q", name, data, "20")
+		}
+	}
+}
+
+func TestApplyDelta(t *testing.T) {
+	base := []byte("hello world")
+	// base size 11, result size 13, copy 6 bytes at offset 0, insert "gopher!"
+	delta := []byte{11, 13, 0x80 | 0x10, 6, 7, 'g', 'o', 'p', 'h', 'e', 'r', '!'}
+
+	result, err := applyDelta(base, delta)
+	if err != nil {
+		t.Fatalf("applyDelta() failed: %v", err)
+	}
+	if string(result) != "hello gopher!" {
+		t.Errorf("applyDelta() = %q, want %q", result, "hello gopher!")
+	}
+
+	if _, err := applyDelta([]byte("short"), delta); err == nil {
+		t.Errorf("Expected error for mismatched base size")
+	}
+}
+
+func TestInflate(t *testing.T) {
+	var compressed bytes.Buffer
+	zw := zlib.NewWriter(&compressed)
+	zw.Write([]byte("hello world"))
+	zw.Close()
+
+	data, err := inflate(bytes.NewReader(compressed.Bytes()), 11)
+	if err != nil {
+		t.Fatalf("inflate() failed: %v", err)
+	}
+	if string(data) != "hello world" {
+		t.Errorf("inflate() = %q, want %q", data, "hello world")
+	}
+
+	// A size that does not match the data fails instead of allocating or truncating
+	for _, size := range []uint64{5, 12, 1 << 40} {
+		if _, err := inflate(bytes.NewReader(compressed.Bytes()), size); err == nil {
+			t.Errorf("Expected error for header size %d", size)
+		}
+	}
+}
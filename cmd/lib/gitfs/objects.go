@@ -0,0 +1,92 @@
+package gitfs
+
+import (
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// readObject returns the type and content of an object, looking at loose
+// objects first and then at every pack
+func (r *Repository) readObject(hash Hash) (string, []byte, error) {
+	objType, data, err := r.readLooseObject(hash)
+	if err == nil {
+		return objType, data, nil
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		return "", nil, err
+	}
+
+	packs, err := r.loadPacks()
+	if err != nil {
+		return "", nil, err
+	}
+	for _, pack := range packs {
+		if offset, ok := pack.find(hash); ok {
+			return pack.readObject(r, offset)
+		}
+	}
+
+	return "", nil, fmt.Errorf("object %s not found", hash)
+}
+
+// readLooseObject reads a zlib compressed object from objects/xx/yyyy
+func (r *Repository) readLooseObject(hash Hash) (string, []byte, error) {
+	name := hash.String()
+	file, err := os.Open(filepath.Join(r.commonDir, "objects", name[:2], name[2:]))
+	if err != nil {
+		return "", nil, err
+	}
+	defer file.Close()
+
+	zr, err := zlib.NewReader(file)
+	if err != nil {
+		return "", nil, fmt.Errorf("reading object %s: %w", name, err)
+	}
+	defer zr.Close()
+
+	raw, err := io.ReadAll(zr)
+	if err != nil {
+		return "", nil, fmt.Errorf("reading object %s: %w", name, err)
+	}
+
+	// Loose objects start with a "<type> <size>\x00" header
+	header, data, ok := bytes.Cut(raw, []byte{0})
+	if !ok {
+		return "", nil, fmt.Errorf("invalid object %s", name)
+	}
+	objType, size, ok := bytes.Cut(header, []byte(" "))
+	if !ok || strconv.Itoa(len(data)) != string(size) {
+		return "", nil, fmt.Errorf("invalid object header in %s", name)
+	}
+
+	return string(objType), data, nil
+}
+
+// loadPacks reads the index of every pack in objects/pack once
+func (r *Repository) loadPacks() ([]*packFile, error) {
+	if r.packsLoaded {
+		return r.packs, nil
+	}
+	r.packsLoaded = true
+
+	indexes, err := filepath.Glob(filepath.Join(r.commonDir, "objects", "pack", "*.idx"))
+	if err != nil {
+		return nil, err
+	}
+	for _, index := range indexes {
+		pack, err := openPack(index)
+		if err != nil {
+			return nil, err
+		}
+		r.packs = append(r.packs, pack)
+	}
+
+	return r.packs, nil
+}
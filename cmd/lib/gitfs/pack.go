@@ -0,0 +1,324 @@
+package gitfs
+
+import (
+	"bufio"
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"strings"
+)
+
+// maxDeltaDepth limits the length of delta chains followed in a pack
+const maxDeltaDepth = 100
+
+// Pack object types, see gitformat-pack(5)
+const (
+	packCommit   = 1
+	packTree     = 2
+	packBlob     = 3
+	packTag      = 4
+	packOfsDelta = 6
+	packRefDelta = 7
+)
+
+var packTypeNames = map[byte]string{
+	packCommit: "commit",
+	packTree:   "tree",
+	packBlob:   "blob",
+	packTag:    "tag",
+}
+
+// packFile is a version 2 pack index together with the path of its pack
+type packFile struct {
+	path   string
+	index  []byte
+	fanout [256]uint32
+	count  int
+}
+
+// openPack loads a version 2 .idx file
+func openPack(indexPath string) (*packFile, error) {
+	data, err := os.ReadFile(indexPath)
+	if err != nil {
+		return nil, fmt.Errorf("reading pack index: %w", err)
+	}
+
+	if len(data) < 8+256*4 || !bytes.Equal(data[:4], []byte{0xff, 't', 'O', 'c'}) ||
+		binary.BigEndian.Uint32(data[4:8]) != 2 {
+		return nil, fmt.Errorf("unsupported pack index %s", indexPath)
+	}
+
+	pack := &packFile{
+		path:  strings.TrimSuffix(indexPath, ".idx") + ".pack",
+		index: data,
+	}
+	for i := range pack.fanout {
+		pack.fanout[i] = binary.BigEndian.Uint32(data[8+4*i:])
+	}
+	pack.count = int(pack.fanout[255])
+
+	if len(data) < pack.largeOffsetsStart() {
+		return nil, fmt.Errorf("truncated pack index %s", indexPath)
+	}
+
+	return pack, nil
+}
+
+func (p *packFile) namesStart() int        { return 8 + 256*4 }
+func (p *packFile) offsetsStart() int      { return p.namesStart() + 24*p.count }
+func (p *packFile) largeOffsetsStart() int { return p.offsetsStart() + 4*p.count }
+
+// name returns the object name at position i of the index
+func (p *packFile) name(i int) []byte {
+	start := p.namesStart() + 20*i
+	return p.index[start : start+20]
+}
+
+// bucket returns the index range of names starting with the given byte
+func (p *packFile) bucket(first byte) (int, int) {
+	lo := 0
+	if first > 0 {
+		lo = int(p.fanout[first-1])
+	}
+	return lo, int(p.fanout[first])
+}
+
+// find returns the pack offset of an object
+func (p *packFile) find(hash Hash) (int64, bool) {
+	lo, hi := p.bucket(hash[0])
+	i := lo + sort.Search(hi-lo, func(i int) bool {
+		return bytes.Compare(p.name(lo+i), hash[:]) >= 0
+	})
+	if i >= hi || !bytes.Equal(p.name(i), hash[:]) {
+		return 0, false
+	}
+
+	offset := binary.BigEndian.Uint32(p.index[p.offsetsStart()+4*i:])
+	if offset&0x80000000 == 0 {
+		return int64(offset), true
+	}
+
+	// Offsets above 2GiB are stored in a separate 8 byte table
+	large := p.largeOffsetsStart() + 8*int(offset&0x7fffffff)
+	if large+8 > len(p.index) {
+		return 0, false
+	}
+	return int64(binary.BigEndian.Uint64(p.index[large:])), true
+}
+
+// withPrefix returns every object name in the pack starting with the hex prefix
+func (p *packFile) withPrefix(prefix string) []Hash {
+	first, err := hex.DecodeString(prefix[:2])
+	if err != nil {
+		return nil
+	}
+
+	var hashes []Hash
+	lo, hi := p.bucket(first[0])
+	for i := lo; i < hi; i++ {
+		if strings.HasPrefix(hex.EncodeToString(p.name(i)), prefix) {
+			var hash Hash
+			copy(hash[:], p.name(i))
+			hashes = append(hashes, hash)
+		}
+	}
+	return hashes
+}
+
+// readObject reads and, if needed, undeltifies the object at offset
+func (p *packFile) readObject(r *Repository, offset int64) (string, []byte, error) {
+	file, err := os.Open(p.path)
+	if err != nil {
+		return "", nil, fmt.Errorf("reading pack: %w", err)
+	}
+	defer file.Close()
+
+	return p.readEntry(r, file, offset, 0)
+}
+
+func (p *packFile) readEntry(r *Repository, file *os.File, offset int64, depth int) (string, []byte, error) {
+	if depth > maxDeltaDepth {
+		return "", nil, fmt.Errorf("delta chain too long in %s", p.path)
+	}
+
+	br := bufio.NewReader(io.NewSectionReader(file, offset, 1<<62))
+
+	// The entry header holds the type and the inflated size as a varint
+	c, err := br.ReadByte()
+	if err != nil {
+		return "", nil, fmt.Errorf("reading pack entry: %w", err)
+	}
+	objType := (c >> 4) & 0x7
+	size := uint64(c & 0x0f)
+	for shift := 4; c&0x80 != 0; shift += 7 {
+		if c, err = br.ReadByte(); err != nil {
+			return "", nil, fmt.Errorf("reading pack entry: %w", err)
+		}
+		size |= uint64(c&0x7f) << shift
+	}
+
+	switch objType {
+	case packCommit, packTree, packBlob, packTag:
+		data, err := inflate(br, size)
+		return packTypeNames[objType], data, err
+
+	case packOfsDelta:
+		// The base is stored earlier in the same pack at a relative offset
+		c, err := br.ReadByte()
+		if err != nil {
+			return "", nil, fmt.Errorf("reading delta offset: %w", err)
+		}
+		distance := int64(c & 0x7f)
+		for c&0x80 != 0 {
+			if c, err = br.ReadByte(); err != nil {
+				return "", nil, fmt.Errorf("reading delta offset: %w", err)
+			}
+			distance = ((distance + 1) << 7) | int64(c&0x7f)
+		}
+
+		delta, err := inflate(br, size)
+		if err != nil {
+			return "", nil, err
+		}
+		baseType, base, err := p.readEntry(r, file, offset-distance, depth+1)
+		if err != nil {
+			return "", nil, err
+		}
+		data, err := applyDelta(base, delta)
+		return baseType, data, err
+
+	case packRefDelta:
+		// The base is named by its hash and may live anywhere
+		var baseHash Hash
+		if _, err := io.ReadFull(br, baseHash[:]); err != nil {
+			return "", nil, fmt.Errorf("reading delta base: %w", err)
+		}
+
+		delta, err := inflate(br, size)
+		if err != nil {
+			return "", nil, err
+		}
+		baseType, base, err := r.readObject(baseHash)
+		if err != nil {
+			return "", nil, err
+		}
+		data, err := applyDelta(base, delta)
+		return baseType, data, err
+	}
+
+	return "", nil, fmt.Errorf("unknown pack object type %d in %s", objType, p.path)
+}
+
+// inflate decompresses exactly size bytes of zlib data
+func inflate(r io.Reader, size uint64) ([]byte, error) {
+	zr, err := zlib.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("inflating pack entry: %w", err)
+	}
+	defer zr.Close()
+
+	// The size comes from the pack and is not trusted for allocation:
+	// the buffer grows with the data, read up to one byte past the size
+	if size >= math.MaxInt64 {
+		return nil, fmt.Errorf("inflating pack entry: invalid size %d", size)
+	}
+	var data bytes.Buffer
+	if _, err := io.Copy(&data, io.LimitReader(zr, int64(size)+1)); err != nil {
+		return nil, fmt.Errorf("inflating pack entry: %w", err)
+	}
+	if uint64(data.Len()) != size {
+		return nil, fmt.Errorf("inflating pack entry: got %d bytes, want %d", data.Len(), size)
+	}
+	return data.Bytes(), nil
+}
+
+// applyDelta rebuilds an object from its base and a git delta
+func applyDelta(base, delta []byte) ([]byte, error) {
+	pos := 0
+	readSize := func() (uint64, error) {
+		var size uint64
+		for shift := 0; ; shift += 7 {
+			if pos >= len(delta) {
+				return 0, errors.New("truncated delta header")
+			}
+			c := delta[pos]
+			pos++
+			size |= uint64(c&0x7f) << shift
+			if c&0x80 == 0 {
+				return size, nil
+			}
+		}
+	}
+
+	baseSize, err := readSize()
+	if err != nil {
+		return nil, err
+	}
+	if baseSize != uint64(len(base)) {
+		return nil, errors.New("delta base size mismatch")
+	}
+	resultSize, err := readSize()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]byte, 0, resultSize)
+	for pos < len(delta) {
+		op := delta[pos]
+		pos++
+
+		switch {
+		case op&0x80 != 0:
+			// Copy a range of the base; the low bits say which offset and size bytes follow
+			var offset, length uint64
+			for i := 0; i < 4; i++ {
+				if op&(1<<i) != 0 {
+					if pos >= len(delta) {
+						return nil, errors.New("truncated delta copy")
+					}
+					offset |= uint64(delta[pos]) << (8 * i)
+					pos++
+				}
+			}
+			for i := 0; i < 3; i++ {
+				if op&(0x10<<i) != 0 {
+					if pos >= len(delta) {
+						return nil, errors.New("truncated delta copy")
+					}
+					length |= uint64(delta[pos]) << (8 * i)
+					pos++
+				}
+			}
+			if length == 0 {
+				length = 0x10000
+			}
+			if offset+length > uint64(len(base)) {
+				return nil, errors.New("delta copy out of range")
+			}
+			result = append(result, base[offset:offset+length]...)
+
+		case op != 0:
+			// Insert the next op bytes literally
+			if pos+int(op) > len(delta) {
+				return nil, errors.New("truncated delta insert")
+			}
+			result = append(result, delta[pos:pos+int(op)]...)
+			pos += int(op)
+
+		default:
+			return nil, errors.New("invalid delta opcode")
+		}
+	}
+
+	if uint64(len(result)) != resultSize {
+		return nil, errors.New("delta result size mismatch")
+	}
+	return result, nil
+}
@@ -0,0 +1,443 @@
+// Package gitfs reads files at a git revision straight from the object
+// database of a local repository, without touching the working tree.
+package gitfs
+
+import (
+	"bufio"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// maxRefDepth limits how many symbolic refs are followed
+const maxRefDepth = 10
+
+// Hash is a SHA-1 object name
+type Hash [20]byte
+
+// String returns the hex form of the hash
+func (h Hash) String() string {
+	return hex.EncodeToString(h[:])
+}
+
+// parseHash parses a full 40 character hex object name
+func parseHash(s string) (Hash, bool) {
+	var h Hash
+	if len(s) != 2*len(h) {
+		return h, false
+	}
+	if _, err := hex.Decode(h[:], []byte(s)); err != nil {
+		return h, false
+	}
+	return h, true
+}
+
+// Repository is a local git repository opened for reading
+type Repository struct {
+	workTree  string
+	gitDir    string
+	commonDir string
+
+	packs       []*packFile
+	packsLoaded bool
+	packedRefs  map[string]string
+	trees       map[Hash][]treeEntry
+}
+
+// Open finds the repository containing dir by walking up to the nearest .git
+func Open(dir string) (*Repository, error) {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("resolving path %s: %w", dir, err)
+	}
+
+	for current := absDir; ; {
+		gitPath := filepath.Join(current, ".git")
+		if info, err := os.Stat(gitPath); err == nil {
+			gitDir := gitPath
+			if !info.IsDir() {
+				// Worktrees and submodules use a .git file pointing at the real directory
+				if gitDir, err = readGitFile(gitPath); err != nil {
+					return nil, err
+				}
+			}
+			return newRepository(current, gitDir)
+		}
+
+		parent := filepath.Dir(current)
+		if parent == current {
+			return nil, fmt.Errorf("%s is not inside a git repository", absDir)
+		}
+		current = parent
+	}
+}
+
+// readGitFile resolves the "gitdir: <path>" line of a .git file
+func readGitFile(file string) (string, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return "", fmt.Errorf("reading %s: %w", file, err)
+	}
+
+	line := strings.TrimSpace(string(data))
+	if !strings.HasPrefix(line, "gitdir:") {
+		return "", fmt.Errorf("invalid git file %s", file)
+	}
+
+	gitDir := strings.TrimSpace(strings.TrimPrefix(line, "gitdir:"))
+	if !filepath.IsAbs(gitDir) {
+		gitDir = filepath.Join(filepath.Dir(file), gitDir)
+	}
+	return gitDir, nil
+}
+
+func newRepository(workTree, gitDir string) (*Repository, error) {
+	if _, err := os.Stat(filepath.Join(gitDir, "HEAD")); err != nil {
+		return nil, fmt.Errorf("%s is not a git directory: %w", gitDir, err)
+	}
+
+	// Linked worktrees share objects and refs through a commondir file
+	commonDir := gitDir
+	if data, err := os.ReadFile(filepath.Join(gitDir, "commondir")); err == nil {
+		commonDir = strings.TrimSpace(string(data))
+		if !filepath.IsAbs(commonDir) {
+			commonDir = filepath.Join(gitDir, commonDir)
+		}
+	}
+
+	return &Repository{
+		workTree:  workTree,
+		gitDir:    gitDir,
+		commonDir: commonDir,
+		trees:     make(map[Hash][]treeEntry),
+	}, nil
+}
+
+// WorkTree returns the top-level directory of the repository
+func (r *Repository) WorkTree() string {
+	return r.workTree
+}
+
+// FS returns the tree of the given revision as a read-only file system
+func (r *Repository) FS(rev string) (fs.FS, error) {
+	hash, err := r.Resolve(rev)
+	if err != nil {
+		return nil, err
+	}
+
+	tree, err := r.peelToTree(hash)
+	if err != nil {
+		return nil, fmt.Errorf("resolving %s: %w", rev, err)
+	}
+
+	return &treeFS{repo: r, root: tree}, nil
+}
+
+// Resolve turns a revision such as a branch, tag, remote branch, full or
+// abbreviated commit hash, optionally followed by ~N or ^N, into an object hash
+func (r *Repository) Resolve(rev string) (Hash, error) {
+	name, suffix := rev, ""
+	if i := strings.IndexAny(rev, "~^"); i >= 0 {
+		name, suffix = rev[:i], rev[i:]
+	}
+
+	hash, err := r.resolveName(name)
+	if err != nil {
+		return Hash{}, fmt.Errorf("unknown revision %s: %w", rev, err)
+	}
+
+	for suffix != "" {
+		op := suffix[0]
+		suffix = suffix[1:]
+
+		digits := len(suffix) - len(strings.TrimLeft(suffix, "0123456789"))
+		n := 1
+		if digits > 0 {
+			if n, err = strconv.Atoi(suffix[:digits]); err != nil {
+				return Hash{}, fmt.Errorf("invalid revision %s", rev)
+			}
+			suffix = suffix[digits:]
+		}
+
+		if op == '~' {
+			// ~N follows the first parent N times
+			for i := 0; i < n; i++ {
+				if hash, err = r.parent(hash, 1); err != nil {
+					return Hash{}, fmt.Errorf("resolving %s: %w", rev, err)
+				}
+			}
+		} else if n == 0 {
+			// ^0 peels tags down to the commit itself
+			if hash, err = r.peelToCommit(hash); err != nil {
+				return Hash{}, fmt.Errorf("resolving %s: %w", rev, err)
+			}
+		} else {
+			// ^N selects the Nth parent
+			if hash, err = r.parent(hash, n); err != nil {
+				return Hash{}, fmt.Errorf("resolving %s: %w", rev, err)
+			}
+		}
+	}
+
+	return hash, nil
+}
+
+// resolveName resolves a revision without ~ or ^ suffixes, following the
+// lookup order of git rev-parse
+func (r *Repository) resolveName(name string) (Hash, error) {
+	if name == "" {
+		return Hash{}, errors.New("empty revision")
+	}
+	if hash, ok := parseHash(name); ok {
+		return hash, nil
+	}
+
+	candidates := []string{
+		name,
+		"refs/" + name,
+		"refs/tags/" + name,
+		"refs/heads/" + name,
+		"refs/remotes/" + name,
+		"refs/remotes/" + name + "/HEAD",
+	}
+	for _, ref := range candidates {
+		hash, ok, err := r.readRef(ref, 0)
+		if err != nil {
+			return Hash{}, err
+		}
+		if ok {
+			return hash, nil
+		}
+	}
+
+	if len(name) >= 4 && isHex(name) {
+		return r.expandShortHash(strings.ToLower(name))
+	}
+
+	return Hash{}, errors.New("no matching ref or object")
+}
+
+// readRef reads a loose or packed ref, following symbolic refs
+func (r *Repository) readRef(ref string, depth int) (Hash, bool, error) {
+	if depth > maxRefDepth {
+		return Hash{}, false, fmt.Errorf("too many levels of symbolic refs at %s", ref)
+	}
+
+	// Top-level names are restricted to pseudo refs such as HEAD or FETCH_HEAD
+	// so that files like config or description are never read as refs
+	if !strings.Contains(ref, "/") && strings.ToUpper(ref) != ref {
+		return Hash{}, false, nil
+	}
+
+	for _, dir := range []string{r.gitDir, r.commonDir} {
+		data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(ref)))
+		if err != nil {
+			continue
+		}
+
+		content := strings.TrimSpace(string(data))
+		if target, ok := strings.CutPrefix(content, "ref:"); ok {
+			return r.readRef(strings.TrimSpace(target), depth+1)
+		}
+
+		// FETCH_HEAD holds the hash followed by a description
+		if fields := strings.Fields(content); len(fields) > 0 {
+			if hash, ok := parseHash(fields[0]); ok {
+				return hash, true, nil
+			}
+		}
+		return Hash{}, false, fmt.Errorf("invalid ref %s", ref)
+	}
+
+	packed, err := r.loadPackedRefs()
+	if err != nil {
+		return Hash{}, false, err
+	}
+	if value, ok := packed[ref]; ok {
+		hash, ok := parseHash(value)
+		return hash, ok, nil
+	}
+
+	return Hash{}, false, nil
+}
+
+// loadPackedRefs parses the packed-refs file once
+func (r *Repository) loadPackedRefs() (map[string]string, error) {
+	if r.packedRefs != nil {
+		return r.packedRefs, nil
+	}
+
+	r.packedRefs = make(map[string]string)
+	file, err := os.Open(filepath.Join(r.commonDir, "packed-refs"))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return r.packedRefs, nil
+		}
+		return nil, fmt.Errorf("reading packed refs: %w", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := scanner.Text()
+		// Skip the header and peeled tag lines
+		if strings.HasPrefix(line, "#") || strings.HasPrefix(line, "^") {
+			continue
+		}
+		if hash, ref, ok := strings.Cut(line, " "); ok {
+			r.packedRefs[ref] = hash
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading packed refs: %w", err)
+	}
+
+	return r.packedRefs, nil
+}
+
+// expandShortHash finds the single object whose name starts with prefix
+func (r *Repository) expandShortHash(prefix string) (Hash, error) {
+	matches := make(map[Hash]bool)
+
+	entries, _ := os.ReadDir(filepath.Join(r.commonDir, "objects", prefix[:2]))
+	for _, entry := range entries {
+		if name := prefix[:2] + entry.Name(); strings.HasPrefix(name, prefix) {
+			if hash, ok := parseHash(name); ok {
+				matches[hash] = true
+			}
+		}
+	}
+
+	packs, err := r.loadPacks()
+	if err != nil {
+		return Hash{}, err
+	}
+	for _, pack := range packs {
+		for _, hash := range pack.withPrefix(prefix) {
+			matches[hash] = true
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return Hash{}, errors.New("no matching ref or object")
+	case 1:
+		for hash := range matches {
+			return hash, nil
+		}
+	}
+	return Hash{}, fmt.Errorf("short hash %s is ambiguous", prefix)
+}
+
+// parent returns the nth parent of a commit
+func (r *Repository) parent(hash Hash, n int) (Hash, error) {
+	commit, err := r.peelToCommit(hash)
+	if err != nil {
+		return Hash{}, err
+	}
+
+	_, data, err := r.readObject(commit)
+	if err != nil {
+		return Hash{}, err
+	}
+
+	parents := headerValues(data, "parent")
+	if n > len(parents) {
+		return Hash{}, fmt.Errorf("commit %s has no parent %d", commit, n)
+	}
+
+	parent, ok := parseHash(parents[n-1])
+	if !ok {
+		return Hash{}, fmt.Errorf("invalid parent in commit %s", commit)
+	}
+	return parent, nil
+}
+
+// peelToCommit dereferences annotated tags until a commit is reached
+func (r *Repository) peelToCommit(hash Hash) (Hash, error) {
+	for depth := 0; depth <= maxRefDepth; depth++ {
+		objType, data, err := r.readObject(hash)
+		if err != nil {
+			return Hash{}, err
+		}
+
+		switch objType {
+		case "commit":
+			return hash, nil
+		case "tag":
+			if hash, err = headerHash(data, "object"); err != nil {
+				return Hash{}, err
+			}
+		default:
+			return Hash{}, fmt.Errorf("object %s is a %s, not a commit", hash, objType)
+		}
+	}
+	return Hash{}, fmt.Errorf("too many levels of tags at %s", hash)
+}
+
+// peelToTree dereferences tags and commits until a tree is reached
+func (r *Repository) peelToTree(hash Hash) (Hash, error) {
+	for depth := 0; depth <= maxRefDepth; depth++ {
+		objType, data, err := r.readObject(hash)
+		if err != nil {
+			return Hash{}, err
+		}
+
+		switch objType {
+		case "tree":
+			return hash, nil
+		case "commit":
+			return headerHash(data, "tree")
+		case "tag":
+			if hash, err = headerHash(data, "object"); err != nil {
+				return Hash{}, err
+			}
+		default:
+			return Hash{}, fmt.Errorf("object %s is a %s, not a tree", hash, objType)
+		}
+	}
+	return Hash{}, fmt.Errorf("too many levels of tags at %s", hash)
+}
+
+// headerValues returns the values of a header field in a commit or tag object
+func headerValues(data []byte, field string) []string {
+	var values []string
+	for _, line := range strings.Split(string(data), "\n") {
+		// Headers end at the first blank line
+		if line == "" {
+			break
+		}
+		if value, ok := strings.CutPrefix(line, field+" "); ok {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// headerHash returns the first header field of a commit or tag as a hash
+func headerHash(data []byte, field string) (Hash, error) {
+	values := headerValues(data, field)
+	if len(values) == 0 {
+		return Hash{}, fmt.Errorf("object has no %s header", field)
+	}
+
+	hash, ok := parseHash(values[0])
+	if !ok {
+		return Hash{}, fmt.Errorf("invalid %s header %q", field, values[0])
+	}
+	return hash, nil
+}
+
+func isHex(s string) bool {
+	for _, c := range s {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", c) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,161 @@
+package gitfs
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// runGit runs a git command in dir with a fixed identity and returns its trimmed output
+func runGit(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME=Test", "GIT_AUTHOR_EMAIL=test@example.com",
+		"GIT_COMMITTER_NAME=Test", "GIT_COMMITTER_EMAIL=test@example.com",
+		"GIT_CONFIG_GLOBAL=/dev/null", "GIT_CONFIG_NOSYSTEM=1",
+	)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %s failed: %v\n%s", strings.Join(args, " "), err, output)
+	}
+	return strings.TrimSpace(string(output))
+}
+
+// commitFiles writes the given files and commits them
+func commitFiles(t *testing.T, dir, message string, files map[string]string) {
+	t.Helper()
+
+	for file, content := range files {
+		path := filepath.Join(dir, file)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create directory for %s: %v", file, err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", file, err)
+		}
+	}
+	runGit(t, dir, "add", "-A")
+	runGit(t, dir, "commit", "-q", "-m", message)
+}
+
+// newTestRepo creates a repository with two commits on main, an annotated
+// tag on the first one, a feature branch and a fake remote branch
+func newTestRepo(t *testing.T, packed bool) string {
+	t.Helper()
+
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	dir := t.TempDir()
+	runGit(t, dir, "init", "-q", "-b", "main")
+	commitFiles(t, dir, "first", map[string]string{
+		"go.mod":           "module example.com/app\n\ngo 1.21\n",
+		"web/.nvmrc":       "18\n",
+		"web/package.json": `{"engines": {"node": "18"}}`,
+	})
+	runGit(t, dir, "tag", "-a", "v1.0.0", "-m", "release")
+	commitFiles(t, dir, "second", map[string]string{
+		"go.mod":     "module example.com/app\n\ngo 1.22\n",
+		"web/.nvmrc": "20\n",
+	})
+	runGit(t, dir, "branch", "feature", "HEAD~1")
+	runGit(t, dir, "update-ref", "refs/remotes/origin/main", "HEAD~1")
+
+	if packed {
+		runGit(t, dir, "gc", "-q", "--aggressive")
+	}
+	return dir
+}
+
+func TestResolve(t *testing.T) {
+	for _, packed := range []bool{false, true} {
+		dir := newTestRepo(t, packed)
+		repo, err := Open(dir)
+		if err != nil {
+			t.Fatalf("Open() failed: %v", err)
+		}
+
+		head := runGit(t, dir, "rev-parse", "HEAD")
+		first := runGit(t, dir, "rev-parse", "HEAD~1")
+		tag := runGit(t, dir, "rev-parse", "v1.0.0")
+
+		tests := map[string]string{
+			"HEAD":                     head,
+			"main":                     head,
+			"refs/heads/main":          head,
+			"HEAD~1":                   first,
+			"HEAD^":                    first,
+			"main~0":                   head,
+			"feature":                  first,
+			"origin/main":              first,
+			"v1.0.0":                   tag,
+			"v1.0.0^0":                 first,
+			head:                       head,
+			first[:7]:                  first,
+			strings.ToUpper(head[:10]): head,
+		}
+
+		for rev, expected := range tests {
+			hash, err := repo.Resolve(rev)
+			if err != nil {
+				t.Errorf("Resolve(%q) failed (packed=%v): %v", rev, packed, err)
+				continue
+			}
+			if hash.String() != expected {
+				t.Errorf("Resolve(%q) = %s, want %s (packed=%v)", rev, hash, expected, packed)
+			}
+		}
+
+		for _, rev := range []string{"", "missing", "HEAD~5", "config", "zzzz"} {
+			if _, err := repo.Resolve(rev); err == nil {
+				t.Errorf("Expected error resolving %q (packed=%v)", rev, packed)
+			}
+		}
+	}
+}
+
+func TestOpenFromSubdirectory(t *testing.T) {
+	dir := newTestRepo(t, false)
+
+	repo, err := Open(filepath.Join(dir, "web"))
+	if err != nil {
+		t.Fatalf("Open() failed: %v", err)
+	}
+
+	expected, _ := filepath.EvalSymlinks(dir)
+	actual, _ := filepath.EvalSymlinks(repo.WorkTree())
+	if actual != expected {
+		t.Errorf("WorkTree() = %q, want %q", actual, expected)
+	}
+}
+
+func TestOpenOutsideRepository(t *testing.T) {
+	if _, err := Open(t.TempDir()); err == nil {
+		t.Errorf("Expected error opening a directory outside a git repository")
+	}
+}
+
+func TestOpenLinkedWorktree(t *testing.T) {
+	dir := newTestRepo(t, true)
+	worktree := filepath.Join(t.TempDir(), "wt")
+	runGit(t, dir, "worktree", "add", "-q", worktree, "feature")
+
+	repo, err := Open(worktree)
+	if err != nil {
+		t.Fatalf("Open() failed: %v", err)
+	}
+
+	hash, err := repo.Resolve("HEAD")
+	if err != nil {
+		t.Fatalf("Resolve(HEAD) failed: %v", err)
+	}
+	if expected := runGit(t, dir, "rev-parse", "feature"); hash.String() != expected {
+		t.Errorf("Resolve(HEAD) = %s, want %s", hash, expected)
+	}
+}
@@ -1,6 +1,7 @@
 package versioncheck
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -10,6 +11,8 @@ import (
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/stacktodate/stacktodate-cli/internal/version"
 )
 
 const (
@@ -20,6 +23,14 @@ const (
 	httpTimeout   = 10 * time.Second
 )
 
+// httpClient fetches release information
+var httpClient = http.DefaultClient
+
+// SetHTTPClient changes the client used to fetch release information
+func SetHTTPClient(client *http.Client) {
+	httpClient = client
+}
+
 // getUserHomeDir returns the user's home directory (can be overridden for testing)
 var getUserHomeDir = os.UserHomeDir
 
@@ -117,19 +128,18 @@ func SaveCache(latestVersion, releaseURL string) error {
 
 // FetchLatestFromGitHub fetches the latest release information from GitHub API
 func FetchLatestFromGitHub() (*GitHubRelease, error) {
-	client := &http.Client{
-		Timeout: httpTimeout,
-	}
+	ctx, cancel := context.WithTimeout(context.Background(), httpTimeout)
+	defer cancel()
 
-	req, err := http.NewRequest("GET", githubAPIURL, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", githubAPIURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("creating request: %w", err)
 	}
 
 	// GitHub API requires User-Agent header
-	req.Header.Set("User-Agent", "stacktodate-cli")
+	req.Header.Set("User-Agent", "stacktodate-cli/"+version.GetVersion())
 
-	resp, err := client.Do(req)
+	resp, err := httpClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("fetching from GitHub: %w", err)
 	}
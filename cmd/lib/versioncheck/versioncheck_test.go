@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
@@ -164,7 +165,7 @@ func TestFetchLatestFromGitHub(t *testing.T) {
 	// Create a test server that mimics GitHub API
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Verify User-Agent header
-		if ua := r.Header.Get("User-Agent"); ua != "stacktodate-cli" {
+		if ua := r.Header.Get("User-Agent"); !strings.HasPrefix(ua, "stacktodate-cli/") {
 			w.WriteHeader(http.StatusBadRequest)
 			return
 		}
@@ -179,8 +180,20 @@ func TestFetchLatestFromGitHub(t *testing.T) {
 	}))
 	defer server.Close()
 
-	// TODO: This test would need to mock the HTTP client to test properly
-	// For now, we'll test the parsing logic independently
+	t.Run("fetch through the configured client", func(t *testing.T) {
+		original := httpClient
+		defer SetHTTPClient(original)
+		SetHTTPClient(&http.Client{Transport: redirectTransport{server.URL}})
+
+		release, err := FetchLatestFromGitHub()
+		if err != nil {
+			t.Fatalf("FetchLatestFromGitHub failed: %v", err)
+		}
+		if release.TagName != "v0.3.0" {
+			t.Fatalf("expected tag v0.3.0, got %s", release.TagName)
+		}
+	})
+
 	t.Run("parse release response", func(t *testing.T) {
 		jsonData := `{
 			"tag_name": "v0.3.0",
@@ -203,6 +216,21 @@ func TestFetchLatestFromGitHub(t *testing.T) {
 	})
 }
 
+// redirectTransport sends every request to a test server instead of its host
+type redirectTransport struct {
+	target string
+}
+
+func (t redirectTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	target, err := url.Parse(t.target)
+	if err != nil {
+		return nil, err
+	}
+	r := req.Clone(req.Context())
+	r.URL.Scheme, r.URL.Host = target.Scheme, target.Host
+	return http.DefaultTransport.RoundTrip(r)
+}
+
 func TestGetLatestVersionWithCache(t *testing.T) {
 	tmpDir := t.TempDir()
 
@@ -0,0 +1,214 @@
+package versions
+
+import (
+	"fmt"
+	"strings"
+)
+
+// operators are the supported comparison operators, longest first so that
+// "~>" is not read as "~"
+var operators = []string{"~>", "~=", ">=", "<=", "!=", "==", "^", "~", ">", "<", "="}
+
+// Constraint is a version requirement such as "3.2", "~> 3.2", ">=18 <21", "3.x"
+// or "^1.2 || ^2". Space- or comma-separated terms must all hold, and "||"
+// separates alternatives.
+type Constraint struct {
+	raw          string
+	alternatives [][]term
+}
+
+// term is a single comparison; a version without operator or with a wildcard
+// such as 3.x matches every version starting with its segments
+type term struct {
+	op      string
+	version Version
+	any     bool // "*" or "x"
+}
+
+// ParseConstraint parses a version constraint
+func ParseConstraint(s string) (Constraint, error) {
+	c := Constraint{raw: strings.TrimSpace(s)}
+	if c.raw == "" {
+		return c, fmt.Errorf("empty version constraint")
+	}
+
+	for _, alternative := range strings.Split(c.raw, "||") {
+		fields := strings.Fields(strings.ReplaceAll(alternative, ",", " "))
+		if len(fields) == 0 {
+			return c, fmt.Errorf("invalid version constraint %q", c.raw)
+		}
+
+		var terms []term
+		for i := 0; i < len(fields); i++ {
+			field := fields[i]
+			// Operators may be separated from their version, e.g. "~> 3.2"
+			if isOperator(field) && i+1 < len(fields) {
+				i++
+				field += fields[i]
+			}
+
+			t, err := parseTerm(field)
+			if err != nil {
+				return c, fmt.Errorf("invalid version constraint %q: %w", c.raw, err)
+			}
+			terms = append(terms, t)
+		}
+		c.alternatives = append(c.alternatives, terms)
+	}
+
+	return c, nil
+}
+
+func isOperator(s string) bool {
+	for _, op := range operators {
+		if s == op {
+			return true
+		}
+	}
+	return false
+}
+
+func parseTerm(s string) (term, error) {
+	var t term
+	for _, op := range operators {
+		if strings.HasPrefix(s, op) {
+			t.op = op
+			s = s[len(op):]
+			break
+		}
+	}
+
+	// Wildcards only make sense as an exact match, e.g. "3.x" or "3.2.*"
+	wildcard := s
+	for _, w := range []string{".x", ".X", ".*"} {
+		wildcard = strings.TrimSuffix(wildcard, w)
+	}
+	if wildcard == "*" || wildcard == "x" || wildcard == "X" {
+		t.any = true
+		return t, nil
+	}
+
+	v, err := Parse(wildcard)
+	if err != nil {
+		return t, err
+	}
+	if strings.HasPrefix(v.suffix, ".") || (v.suffix != "" && wildcard != s) {
+		return t, fmt.Errorf("invalid version %q", s)
+	}
+	t.version = v
+	return t, nil
+}
+
+// Check reports whether v satisfies the constraint
+func (c Constraint) Check(v Version) bool {
+	for _, terms := range c.alternatives {
+		ok := true
+		for _, t := range terms {
+			if !t.check(v) {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			return true
+		}
+	}
+	return false
+}
+
+// check compares v with the term's version. Equality compares as many segments as the
+// term has, so that "18" matches 18.19.0, while ordering compares full versions, so that
+// ">3.2" allows 3.2.5 and "<=20" does not allow 20.5.
+func (t term) check(v Version) bool {
+	if t.any {
+		return true
+	}
+
+	n := t.version.Len()
+	cmp := compareSegments(v, t.version, n)
+
+	switch t.op {
+	case "", "=", "==":
+		return cmp == 0
+	case "!=":
+		return cmp != 0
+	case ">":
+		return v.Compare(t.version) > 0
+	case ">=":
+		return cmp >= 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return v.Compare(t.version) <= 0
+	case "~>", "~=":
+		// Pessimistic: ~> 3.2 allows 3.2 up to 4.0, ~> 3.2.1 allows 3.2.1 up to 3.3
+		fixed := n - 1
+		if fixed < 1 {
+			fixed = 1
+		}
+		return cmp >= 0 && compareSegments(v, t.version, fixed) == 0
+	case "~":
+		// Tilde: ~1.2.3 and ~1.2 allow patch changes, ~1 allows minor changes
+		fixed := 2
+		if n < fixed {
+			fixed = n
+		}
+		return cmp >= 0 && compareSegments(v, t.version, fixed) == 0
+	case "^":
+		// Caret: changes to the left-most non-zero segment are not allowed
+		fixed := n
+		for i := 0; i < n; i++ {
+			if t.version.Segment(i) != 0 {
+				fixed = i + 1
+				break
+			}
+		}
+		return cmp >= 0 && compareSegments(v, t.version, fixed) == 0
+	}
+	return false
+}
+
+// Min returns the lowest version allowed by the constraint, if it has a lower bound.
+// For an exclusive bound such as ">3.2" it returns the bound itself.
+func (c Constraint) Min() (Version, bool) {
+	v, _, ok := c.lowerBound()
+	return v, ok
+}
+
+// lowerBound returns the lower bound of the constraint and whether it is exclusive, as for ">3.2"
+func (c Constraint) lowerBound() (Version, bool, bool) {
+	var lowest Version
+	lowestExclusive, found := false, false
+
+	for _, terms := range c.alternatives {
+		var bound Version
+		exclusive, hasBound := false, false
+		for _, t := range terms {
+			if t.any {
+				continue
+			}
+			switch t.op {
+			case "", "=", "==", ">=", ">", "~>", "~=", "~", "^":
+				cmp := t.version.Compare(bound)
+				if !hasBound || cmp > 0 || (cmp == 0 && t.op == ">") {
+					bound, exclusive, hasBound = t.version, t.op == ">", true
+				}
+			}
+		}
+		if !hasBound {
+			// An alternative without lower bound makes the whole constraint unbounded
+			return Version{}, false, false
+		}
+		cmp := bound.Compare(lowest)
+		if !found || cmp < 0 || (cmp == 0 && !exclusive) {
+			lowest, lowestExclusive, found = bound, exclusive, true
+		}
+	}
+
+	return lowest.Truncate(lowest.Len()), lowestExclusive, found
+}
+
+// String returns the constraint as written
+func (c Constraint) String() string {
+	return c.raw
+}
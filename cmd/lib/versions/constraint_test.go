@@ -0,0 +1,115 @@
+package versions
+
+import (
+	"testing"
+)
+
+func TestConstraintCheck(t *testing.T) {
+	tests := []struct {
+		constraint string
+		version    string
+		expected   bool
+	}{
+		// Plain versions match every version starting with their segments
+		{"3.2", "3.2.2", true},
+		{"18", "18.19.0", true},
+		{"3.2.0", "3.2", true},
+		{"3.2.2", "3.2", false},
+		{"3.2", "3.3.0", false},
+
+		// Wildcards
+		{"3.x", "3.4.1", true},
+		{"3.2.*", "3.2.9", true},
+		{"3.x", "4.0", false},
+		{"*", "1.0", true},
+
+		// Ruby and PEP 440 pessimistic operators
+		{"~> 3.2", "3.9", true},
+		{"~> 3.2", "3.1", false},
+		{"~> 3.2", "4.0", false},
+		{"~> 3.2.1", "3.2.5", true},
+		{"~> 3.2.1", "3.3.0", false},
+		{"~=3.11", "3.12", true},
+
+		// Ranges
+		{">=18 <21", "20.11.0", true},
+		{">=18 <21", "21", false},
+		{">=18 <21", "16.20", false},
+		{">= 3.0, < 4", "3.3", true},
+		{"<=20", "20.5", false},
+		{"<=20", "20.0.0", true},
+		{">20", "20.5", true},
+		{">20", "20", false},
+		{">3.2", "3.2.5", true},
+		{">3.2", "3.2", false},
+		{"!=3.1", "3.1.4", false},
+
+		// npm tilde and caret
+		{"~1.2.3", "1.2.9", true},
+		{"~1.2.3", "1.3.0", false},
+		{"~1", "1.9", true},
+		{"^1.2", "1.9.0", true},
+		{"^1.2", "2.0.0", false},
+		{"^0.2.3", "0.2.9", true},
+		{"^0.2.3", "0.3.0", false},
+
+		// Alternatives
+		{"^18 || ^20", "20.1.0", true},
+		{"^18 || ^20", "19.0.0", false},
+	}
+
+	for _, tt := range tests {
+		c, err := ParseConstraint(tt.constraint)
+		if err != nil {
+			t.Errorf("ParseConstraint(%q) unexpected error: %v", tt.constraint, err)
+			continue
+		}
+		v, err := Parse(tt.version)
+		if err != nil {
+			t.Errorf("Parse(%q) unexpected error: %v", tt.version, err)
+			continue
+		}
+		if result := c.Check(v); result != tt.expected {
+			t.Errorf("%q.Check(%q) = %v, want %v", tt.constraint, tt.version, result, tt.expected)
+		}
+	}
+}
+
+func TestParseConstraintErrors(t *testing.T) {
+	for _, input := range []string{"", "lts/iron", ">= 18 ||", "3.x-beta"} {
+		if _, err := ParseConstraint(input); err == nil {
+			t.Errorf("ParseConstraint(%q): expected error", input)
+		}
+	}
+}
+
+func TestConstraintMin(t *testing.T) {
+	tests := []struct {
+		constraint string
+		expected   string
+		found      bool
+	}{
+		{"3.2", "3.2", true},
+		{"~> 3.2", "3.2", true},
+		{">=18 <21", "18", true},
+		{"^20 || ^18", "18", true},
+		{"3.x", "3", true},
+		{">3.2", "3.2", true},
+		{">3.2 <4", "3.2", true},
+		{">=3.3 >3.2", "3.3", true},
+		{"<21", "", false},
+		{"^18 || <16", "", false},
+	}
+
+	for _, tt := range tests {
+		c, err := ParseConstraint(tt.constraint)
+		if err != nil {
+			t.Errorf("ParseConstraint(%q) unexpected error: %v", tt.constraint, err)
+			continue
+		}
+		v, found := c.Min()
+		if found != tt.found || v.String() != tt.expected {
+			t.Errorf("%q.Min() = %q, %v, want %q, %v", tt.constraint, v.String(), found, tt.expected, tt.found)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package versions
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Syntax identifies how a version spec is written in the file it was read from
+type Syntax int
+
+const (
+	Plain       Syntax = iota // A version or constraint in the common syntax, e.g. "3.2.2" or "~> 3.2"
+	Gem                       // RubyGems requirements, e.g. "~> 7.1" or ">= 3.0, < 4"
+	NPM                       // npm and Composer ranges, e.g. "^18 || ^20", ">=18 <21" or "1.2 - 2.3"
+	PEP440                    // Python version specifiers, e.g. ">=3.9,<3.12", "~=3.11" or "==3.11.*"
+	GoToolchain               // Go versions, e.g. "1.22" or "go1.22rc1"
+	DockerTag                 // Image tags, e.g. "3.11.4-slim" or "18-alpine"
+)
+
+// ParseSpec parses a version spec written in the given syntax into a constraint.
+// Exact versions such as Docker tags become constraints matching that version only.
+func ParseSpec(syntax Syntax, spec string) (Constraint, error) {
+	spec = strings.TrimSpace(spec)
+
+	switch syntax {
+	case NPM:
+		return parseNPMRange(spec)
+	case PEP440:
+		// Arbitrary equality is the same as a version match for numeric versions
+		return ParseConstraint(strings.ReplaceAll(spec, "===", "=="))
+	case GoToolchain:
+		return parseExact(strings.TrimPrefix(spec, "go"))
+	case DockerTag:
+		return parseExact(spec)
+	}
+	return ParseConstraint(spec)
+}
+
+// parseExact parses a single version, ignoring suffixes such as "rc1" or "-slim"
+func parseExact(s string) (Constraint, error) {
+	v, err := Parse(s)
+	if err != nil {
+		return Constraint{}, err
+	}
+	version := v.Truncate(v.Len())
+	return Constraint{raw: s, alternatives: [][]term{{{version: version}}}}, nil
+}
+
+// parseNPMRange rewrites hyphen ranges and Composer's single "|" before parsing. Partial
+// versions are X-ranges, so ">1.2" becomes ">=1.3" and "<=20" becomes "<21".
+func parseNPMRange(spec string) (Constraint, error) {
+	alternatives := strings.Split(strings.ReplaceAll(spec, "||", "|"), "|")
+	for i, alternative := range alternatives {
+		fields := strings.Fields(alternative)
+		if len(fields) == 3 && fields[1] == "-" {
+			alternatives[i] = ">=" + fields[0] + " <=" + fields[2]
+		}
+	}
+
+	c, err := ParseConstraint(strings.Join(alternatives, " || "))
+	if err != nil {
+		return c, fmt.Errorf("invalid version range %q", spec)
+	}
+	c.raw = spec
+
+	for _, terms := range c.alternatives {
+		for i, t := range terms {
+			if t.any || t.version.Len() >= 3 {
+				continue
+			}
+			switch t.op {
+			case ">":
+				terms[i].op, terms[i].version = ">=", t.version.bumpLast()
+			case "<=":
+				terms[i].op, terms[i].version = "<", t.version.bumpLast()
+			}
+		}
+	}
+	return c, nil
+}
+
+// Cycles returns the lowest and highest of the given release cycles that the constraint
+// allows. A cycle is allowed when it satisfies the constraint, or when the lowest allowed
+// version belongs to it, so that "~> 3.2.1" resolves to the 3.2 cycle. An exclusive bound
+// such as ">3.2" allows 3.2.1 and so resolves to the 3.2 cycle too; npm, where ">3.2"
+// excludes every 3.2.x, has already rewritten it to ">=3.3" in ParseSpec.
+func (c Constraint) Cycles(cycles []string) (lowest, highest string, ok bool) {
+	min, _, hasMin := c.lowerBound()
+
+	var low, high Version
+	for _, cycle := range cycles {
+		v, err := Parse(cycle)
+		if err != nil || v.Suffix() != "" {
+			continue
+		}
+
+		allowed := c.Check(v)
+		if !allowed && hasMin {
+			allowed = compareSegments(min, v, v.Len()) == 0
+		}
+		if !allowed {
+			continue
+		}
+
+		if !ok || v.Compare(low) < 0 || (v.Compare(low) == 0 && v.Len() > low.Len()) {
+			low, lowest = v, cycle
+		}
+		if !ok || v.Compare(high) > 0 {
+			high, highest = v, cycle
+		}
+		ok = true
+	}
+
+	return lowest, highest, ok
+}
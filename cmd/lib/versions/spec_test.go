@@ -0,0 +1,109 @@
+package versions
+
+import (
+	"testing"
+)
+
+func TestParseSpec(t *testing.T) {
+	tests := []struct {
+		syntax   Syntax
+		spec     string
+		version  string
+		expected bool
+	}{
+		{Gem, "~> 7.1", "7.2.0", true},
+		{Gem, ">= 3.0, < 4", "4.0", false},
+		{Gem, "> 3.2", "3.2.5", true},
+		{Gem, "<= 3.2", "3.2.1", false},
+		{NPM, "^18 || ^20", "20.11.1", true},
+		{NPM, "^18 || ^20", "19.0.0", false},
+		{NPM, "1.2 - 2.3", "2.3.9", true},
+		{NPM, "1.2 - 2.3", "2.4.0", false},
+		{NPM, "^7.4|^8.0", "8.2", true},
+		{NPM, "<=20", "20.5.1", true},
+		{NPM, "<=20", "21.0.0", false},
+		{NPM, ">1.2", "1.2.9", false},
+		{NPM, ">1.2", "1.3.0", true},
+		{NPM, ">1.2.3", "1.2.4", true},
+		{PEP440, ">=3.9,<3.12", "3.11.4", true},
+		{PEP440, ">=3.9,<3.12", "3.12.0", false},
+		{PEP440, "==3.11.*", "3.11.9", true},
+		{PEP440, ">3.11", "3.11.4", true},
+		{PEP440, "<=3.11", "3.11.4", false},
+		{PEP440, "===3.11", "3.11", true},
+		{GoToolchain, "go1.22rc1", "1.22", true},
+		{GoToolchain, "1.22.3", "1.22.4", false},
+		{DockerTag, "3.11.4-slim", "3.11.4", true},
+		{DockerTag, "18-alpine", "18.20.0", true},
+	}
+
+	for _, tt := range tests {
+		c, err := ParseSpec(tt.syntax, tt.spec)
+		if err != nil {
+			t.Errorf("ParseSpec(%d, %q) unexpected error: %v", tt.syntax, tt.spec, err)
+			continue
+		}
+		v, _ := Parse(tt.version)
+		if result := c.Check(v); result != tt.expected {
+			t.Errorf("ParseSpec(%d, %q).Check(%q) = %v, want %v", tt.syntax, tt.spec, tt.version, result, tt.expected)
+		}
+	}
+}
+
+func TestParseSpecErrors(t *testing.T) {
+	tests := []struct {
+		syntax Syntax
+		spec   string
+	}{
+		{NPM, "lts/hydrogen"},
+		{DockerTag, "latest"},
+		{DockerTag, "alpine3.18"},
+		{GoToolchain, "local"},
+	}
+
+	for _, tt := range tests {
+		if _, err := ParseSpec(tt.syntax, tt.spec); err == nil {
+			t.Errorf("ParseSpec(%d, %q): expected error", tt.syntax, tt.spec)
+		}
+	}
+}
+
+func TestConstraintCycles(t *testing.T) {
+	cycles := []string{"3.0", "3.1", "3.2", "3.3", "4.0"}
+
+	tests := []struct {
+		syntax     Syntax
+		constraint string
+		lowest     string
+		highest    string
+		ok         bool
+	}{
+		{Plain, "~> 3.2.1", "3.2", "3.2", true},
+		{Plain, "~> 3.1", "3.1", "3.3", true},
+		{Plain, ">= 3.2", "3.2", "4.0", true},
+		{Plain, "> 3.2", "3.2", "4.0", true},
+		{Plain, ">3.2 <4", "3.2", "3.3", true},
+		{Plain, ">3.2.1", "3.2", "4.0", true},
+		{Plain, ">3", "3.0", "4.0", true},
+		{Plain, "3.x", "3.0", "3.3", true},
+		{Plain, "5.0", "", "", false},
+		{Gem, "> 3.2", "3.2", "4.0", true},
+		{Gem, "> 3.2, < 3.3", "3.2", "3.2", true},
+		{PEP440, ">3.2", "3.2", "4.0", true},
+		{PEP440, ">3.2,<4", "3.2", "3.3", true},
+		{NPM, ">3.2", "3.3", "4.0", true},
+		{NPM, ">3.2.1", "3.2", "4.0", true},
+	}
+
+	for _, tt := range tests {
+		c, err := ParseSpec(tt.syntax, tt.constraint)
+		if err != nil {
+			t.Errorf("ParseSpec(%d, %q) unexpected error: %v", tt.syntax, tt.constraint, err)
+			continue
+		}
+		lowest, highest, ok := c.Cycles(cycles)
+		if lowest != tt.lowest || highest != tt.highest || ok != tt.ok {
+			t.Errorf("ParseSpec(%d, %q).Cycles() = %q, %q, %v, want %q, %q, %v", tt.syntax, tt.constraint, lowest, highest, ok, tt.lowest, tt.highest, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package versions
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Version is a dotted numeric version such as 3.2.2. Segments are kept as written,
+// so that versions such as 22.04 are printed unchanged.
+type Version struct {
+	segments []string
+	suffix   string // Text after the numeric segments, e.g. "-alpine" or "rc1"
+}
+
+// Parse parses a version that starts with numeric segments, e.g. "3.2.2", "v1.22"
+// or "3.11.0-alpine". Anything after the numeric segments is kept as the suffix.
+func Parse(s string) (Version, error) {
+	s = strings.TrimSpace(s)
+	trimmed := strings.TrimPrefix(strings.TrimPrefix(s, "v"), "V")
+
+	v, rest := scan(trimmed)
+	if len(v.segments) == 0 {
+		return Version{}, fmt.Errorf("invalid version %q", s)
+	}
+	v.suffix = rest
+	return v, nil
+}
+
+// Find returns the first version number in s, e.g. 7.1.0 in "~> 7.1.0" or
+// 17.0.9 in "temurin-17.0.9+9"
+func Find(s string) (Version, bool) {
+	for i := 0; i < len(s); i++ {
+		if isDigit(s[i]) {
+			v, rest := scan(s[i:])
+			v.suffix = rest
+			return v, true
+		}
+	}
+	return Version{}, false
+}
+
+// scan reads the dot-separated numeric segments at the start of s
+func scan(s string) (Version, string) {
+	var v Version
+	for {
+		end := 0
+		for end < len(s) && isDigit(s[end]) {
+			end++
+		}
+		if end == 0 {
+			return v, s
+		}
+		v.segments = append(v.segments, s[:end])
+		s = s[end:]
+
+		// Only continue on a dot followed by another segment, so "1.20." keeps its trailing dot
+		if len(s) < 2 || s[0] != '.' || !isDigit(s[1]) {
+			return v, s
+		}
+		s = s[1:]
+	}
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// Len returns the number of numeric segments
+func (v Version) Len() int {
+	return len(v.segments)
+}
+
+// Segment returns the i-th numeric segment, or 0 past the last one
+func (v Version) Segment(i int) int {
+	if i >= len(v.segments) {
+		return 0
+	}
+	n, err := strconv.Atoi(v.segments[i])
+	if err != nil {
+		// Segments are digits only, so this is an overflow
+		return int(^uint(0) >> 1)
+	}
+	return n
+}
+
+// Suffix returns the text following the numeric segments
+func (v Version) Suffix() string {
+	return v.suffix
+}
+
+// Truncate returns the first n segments of the version, without its suffix
+func (v Version) Truncate(n int) Version {
+	if n > len(v.segments) {
+		n = len(v.segments)
+	}
+	return Version{segments: v.segments[:n]}
+}
+
+// bumpLast returns the version with its last segment incremented, e.g. 1.3 for 1.2
+func (v Version) bumpLast() Version {
+	segments := append([]string(nil), v.segments...)
+	last := len(segments) - 1
+	segments[last] = strconv.Itoa(v.Segment(last) + 1)
+	return Version{segments: segments}
+}
+
+// String returns the numeric segments of the version, e.g. "3.11.0" for "3.11.0-alpine"
+func (v Version) String() string {
+	return strings.Join(v.segments, ".")
+}
+
+// Compare returns -1, 0 or 1 depending on whether v is lower than, equal to or
+// higher than other. Missing segments count as 0 and suffixes are ignored.
+func (v Version) Compare(other Version) int {
+	n := len(v.segments)
+	if len(other.segments) > n {
+		n = len(other.segments)
+	}
+	return compareSegments(v, other, n)
+}
+
+// compareSegments compares the first n segments of two versions
+func compareSegments(a, b Version, n int) int {
+	for i := 0; i < n; i++ {
+		x, y := a.Segment(i), b.Segment(i)
+		if x < y {
+			return -1
+		}
+		if x > y {
+			return 1
+		}
+	}
+	return 0
+}
@@ -0,0 +1,87 @@
+package versions
+
+import (
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+		suffix   string
+		wantErr  bool
+	}{
+		{"3.2.2", "3.2.2", "", false},
+		{"v1.22", "1.22", "", false},
+		{"  18  ", "18", "", false},
+		{"3.11.0-alpine", "3.11.0", "-alpine", false},
+		{"22.04", "22.04", "", false},
+		{"1.20.", "1.20", ".", false},
+		{"3.13.0rc1", "3.13.0", "rc1", false},
+		{"lts/iron", "", "", true},
+		{"", "", "", true},
+	}
+
+	for _, tt := range tests {
+		v, err := Parse(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Parse(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if v.String() != tt.expected || v.Suffix() != tt.suffix {
+			t.Errorf("Parse(%q) = %q with suffix %q, want %q with suffix %q", tt.input, v.String(), v.Suffix(), tt.expected, tt.suffix)
+		}
+	}
+}
+
+func TestFind(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+		found    bool
+	}{
+		{"~> 7.1.0", "7.1.0", true},
+		{"^ 16.0.0", "16.0.0", true},
+		{"temurin-17.0.9+9", "17.0.9", true},
+		{"system", "", false},
+	}
+
+	for _, tt := range tests {
+		v, found := Find(tt.input)
+		if found != tt.found || v.String() != tt.expected {
+			t.Errorf("Find(%q) = %q, %v, want %q, %v", tt.input, v.String(), found, tt.expected, tt.found)
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"1.2.3", "1.2.3", 0},
+		{"1.2", "1.2.0", 0},
+		{"1.10", "1.9", 1},
+		{"2", "10", -1},
+		{"3.11.0-alpine", "3.11.0", 0},
+	}
+
+	for _, tt := range tests {
+		a, _ := Parse(tt.a)
+		b, _ := Parse(tt.b)
+		if result := a.Compare(b); result != tt.expected {
+			t.Errorf("Compare(%q, %q) = %d, want %d", tt.a, tt.b, result, tt.expected)
+		}
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	v, _ := Parse("3.11.4-slim")
+
+	if result := v.Truncate(2).String(); result != "3.11" {
+		t.Errorf("Truncate(2) = %q, want %q", result, "3.11")
+	}
+	if result := v.Truncate(5); result.String() != "3.11.4" || result.Suffix() != "" {
+		t.Errorf("Truncate(5) = %q with suffix %q, want %q", result.String(), result.Suffix(), "3.11.4")
+	}
+}
@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/stacktodate/stacktodate-cli/cmd/lib/cache"
+	"github.com/stacktodate/stacktodate-cli/cmd/lib/versions"
+)
+
+// nodeLTSCodenames maps the codenames of the Node.js LTS release lines to their major version
+var nodeLTSCodenames = map[string]string{
+	"argon":    "4",
+	"boron":    "6",
+	"carbon":   "8",
+	"dubnium":  "10",
+	"erbium":   "12",
+	"fermium":  "14",
+	"gallium":  "16",
+	"hydrogen": "18",
+	"iron":     "20",
+	"jod":      "22",
+	"krypton":  "24",
+}
+
+// nodeLastLTSCodename is the latest codename in nodeLTSCodenames. Later LTS lines
+// continue the alphabet, e.g. lithium for the first LTS release after krypton.
+const nodeLastLTSCodename = "krypton"
+
+// resolveNodeAliases replaces nvm aliases such as lts/iron, lts/* and node in Node.js
+// candidates with the major version they point to, keeping the alias as the spec.
+// Ranges such as ">=lts/hydrogen" in package.json engines are resolved term by term.
+func resolveNodeAliases(candidates []Candidate, releases []cache.Release) []Candidate {
+	for i := range candidates {
+		candidate := &candidates[i]
+
+		fields := strings.Fields(candidate.Value)
+		resolved := false
+		for j, field := range fields {
+			alias := strings.TrimLeft(field, "~^<>=")
+			if version, ok := nodeAliasVersion(alias, releases); ok {
+				fields[j] = field[:len(field)-len(alias)] + version
+				resolved = true
+			}
+		}
+
+		if resolved {
+			if candidate.Spec == "" {
+				candidate.Spec = strings.TrimSpace(candidate.Value)
+			}
+			candidate.Value = strings.Join(fields, " ")
+		}
+	}
+	return candidates
+}
+
+// nodeAliasVersion returns the major version an nvm alias points to: "node" is the
+// latest release, "lts/*" the latest LTS release, "lts/-1" the one before it and
+// "lts/<codename>" the named LTS release line
+func nodeAliasVersion(alias string, releases []cache.Release) (string, bool) {
+	alias = strings.ToLower(alias)
+
+	switch alias {
+	case "node", "stable", "latest":
+		return latestRelease(releases, false, 0)
+	case "lts/*", "lts":
+		return latestRelease(releases, true, 0)
+	}
+
+	name, ok := strings.CutPrefix(alias, "lts/")
+	if !ok || name == "" {
+		return "", false
+	}
+
+	if offset, err := strconv.Atoi(name); err == nil && offset < 0 {
+		return latestRelease(releases, true, -offset)
+	}
+
+	return ltsCodenameRelease(name, releases)
+}
+
+// ltsCodenameRelease returns the release cycle of an LTS codename. Codenames past
+// nodeLastLTSCodename are counted through the catalog's LTS releases after it, and
+// are not resolved until the catalog has that release.
+func ltsCodenameRelease(name string, releases []cache.Release) (string, bool) {
+	if version, ok := nodeLTSCodenames[name]; ok {
+		return version, true
+	}
+
+	for _, c := range name {
+		if c < 'a' || c > 'z' {
+			return "", false
+		}
+	}
+	if name[0] <= nodeLastLTSCodename[0] {
+		return "", false
+	}
+
+	last, _ := versions.Parse(nodeLTSCodenames[nodeLastLTSCodename])
+	var later []versions.Version
+	for _, cycle := range sortedCycles(releases, true) {
+		if cycle.Compare(last) > 0 {
+			later = append(later, cycle)
+		}
+	}
+
+	index := int(name[0]) - int(nodeLastLTSCodename[0]) - 1
+	if index >= len(later) {
+		return "", false
+	}
+	return later[index].String(), true
+}
+
+// latestRelease returns the release cycle skip releases before the latest one,
+// counting only LTS releases when lts is set
+func latestRelease(releases []cache.Release, lts bool, skip int) (string, bool) {
+	cycles := sortedCycles(releases, lts)
+	if skip >= len(cycles) {
+		return "", false
+	}
+	return cycles[len(cycles)-1-skip].String(), true
+}
+
+// sortedCycles returns the release cycles in ascending order, only LTS ones when lts is set
+func sortedCycles(releases []cache.Release, lts bool) []versions.Version {
+	var cycles []versions.Version
+	for _, release := range releases {
+		if lts && !release.LTS {
+			continue
+		}
+		if v, err := versions.Parse(release.ReleaseCycle); err == nil {
+			cycles = append(cycles, v)
+		}
+	}
+
+	sort.Slice(cycles, func(i, j int) bool { return cycles[i].Compare(cycles[j]) < 0 })
+	return cycles
+}
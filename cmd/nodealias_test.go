@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stacktodate/stacktodate-cli/cmd/lib/cache"
+)
+
+var nodeReleases = []cache.Release{
+	{ReleaseCycle: "24", LTS: true},
+	{ReleaseCycle: "23", LTS: false},
+	{ReleaseCycle: "22", LTS: true},
+	{ReleaseCycle: "20", LTS: true},
+	{ReleaseCycle: "18", LTS: true},
+	{ReleaseCycle: "25", LTS: false},
+	{ReleaseCycle: "16", LTS: true},
+	{ReleaseCycle: "14", LTS: true},
+	{ReleaseCycle: "12", LTS: true},
+	{ReleaseCycle: "10", LTS: true},
+	{ReleaseCycle: "8", LTS: true},
+	{ReleaseCycle: "6", LTS: true},
+	{ReleaseCycle: "4", LTS: true},
+	{ReleaseCycle: "5", LTS: false},
+	{ReleaseCycle: "0.12", LTS: false},
+}
+
+// truncatedNodeReleases is a catalog that has dropped the oldest LTS releases,
+// with an LTS release 21 added by an overlay
+var truncatedNodeReleases = []cache.Release{
+	{ReleaseCycle: "26", LTS: true},
+	{ReleaseCycle: "24", LTS: true},
+	{ReleaseCycle: "22", LTS: true},
+	{ReleaseCycle: "21", LTS: true},
+	{ReleaseCycle: "20", LTS: true},
+	{ReleaseCycle: "18", LTS: true},
+}
+
+func TestNodeAliasVersion(t *testing.T) {
+	tests := []struct {
+		alias    string
+		releases []cache.Release
+		expected string
+		ok       bool
+	}{
+		{"lts/argon", nodeReleases, "4", true},
+		{"lts/iron", nodeReleases, "20", true},
+		{"lts/Hydrogen", nodeReleases, "18", true},
+		{"lts/krypton", nodeReleases, "24", true},
+		{"lts/hydrogen", nil, "18", true},
+		{"lts/carbon", truncatedNodeReleases, "8", true},
+		{"lts/iron", truncatedNodeReleases, "20", true},
+		{"lts/krypton", truncatedNodeReleases, "24", true},
+		{"lts/lithium", truncatedNodeReleases, "26", true},
+		{"lts/magnesium", truncatedNodeReleases, "", false},
+		{"lts/lithium", nil, "", false},
+		{"lts/*", nil, "", false},
+		{"lts/*", nodeReleases, "24", true},
+		{"lts/-1", nodeReleases, "22", true},
+		{"lts/-11", nodeReleases, "", false},
+		{"node", nodeReleases, "25", true},
+		{"node", nil, "", false},
+		{"lts/unknown", nodeReleases, "", false}, // Past the latest LTS release
+		{"lts/lithium", nodeReleases, "", false},
+		{"lts/lithium", append([]cache.Release{{ReleaseCycle: "26", LTS: true}}, nodeReleases...), "26", true},
+		{"lts/iron-2", nodeReleases, "", false},
+		{"lts/ironclad", nodeReleases, "", false},
+		{"lts/boat", nodeReleases, "", false},
+		{"20", nodeReleases, "", false},
+	}
+
+	for _, tt := range tests {
+		version, ok := nodeAliasVersion(tt.alias, tt.releases)
+		if version != tt.expected || ok != tt.ok {
+			t.Errorf("nodeAliasVersion(%q) = %q, %v, want %q, %v", tt.alias, version, ok, tt.expected, tt.ok)
+		}
+	}
+}
+
+func TestResolveNodeAliases(t *testing.T) {
+	candidates := []Candidate{
+		{Value: "lts/iron", Source: ".nvmrc"},
+		{Value: ">=lts/hydrogen <25", Source: "package.json"},
+		{Value: "20.11.0", Source: ".nvmrc"},
+		{Value: "lts/*", Source: ".nvmrc"},
+	}
+
+	result := resolveNodeAliases(candidates, nodeReleases)
+
+	expected := []struct {
+		value string
+		spec  string
+	}{
+		{"20", "lts/iron"},
+		{">=18 <25", ">=lts/hydrogen <25"},
+		{"20.11.0", ""},
+		{"24", "lts/*"},
+	}
+	for i, e := range expected {
+		if result[i].Value != e.value || result[i].Spec != e.spec {
+			t.Errorf("resolveNodeAliases()[%d] = %q (spec %q), want %q (spec %q)", i, result[i].Value, result[i].Spec, e.value, e.spec)
+		}
+	}
+
+	// Without the catalog, only the known codenames are resolved
+	unresolved := resolveNodeAliases([]Candidate{{Value: "lts/iron"}, {Value: "lts/*"}}, nil)
+	if unresolved[0].Value != "20" || unresolved[1].Value != "lts/*" {
+		t.Errorf("resolveNodeAliases() without catalog = %q, %q, want %q, %q", unresolved[0].Value, unresolved[1].Value, "20", "lts/*")
+	}
+
+	// The resolved major version then maps to its release cycle
+	resolved := resolveCandidateVersions(result[:1], releaseCycles(nodeReleases))
+	if resolved[0].Value != "20" || resolved[0].Spec != "lts/iron" {
+		t.Errorf("resolveCandidateVersions() = %q (spec %q), want %q (spec %q)", resolved[0].Value, resolved[0].Spec, "20", "lts/iron")
+	}
+}
@@ -0,0 +1,198 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/stacktodate/stacktodate-cli/cmd/helpers"
+	"github.com/spf13/cobra"
+)
+
+var (
+	projectsFormat  string
+	projectsPage    int
+	projectsPerPage int
+	projectsConfirm bool
+)
+
+var projectsCmd = &cobra.Command{
+	Use:   "projects",
+	Short: "Manage your projects on Stack To Date",
+	Long: `List, show, rename and delete the tech stacks of your Stack To Date account.
+
+Every subcommand accepts --format json for scripts.`,
+}
+
+var projectsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List your projects",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if projectsPage < 1 || projectsPerPage < 1 {
+			helpers.ExitWithError(2, "--page and --per-page must be at least 1")
+		}
+
+		token, err := helpers.GetToken()
+		if err != nil {
+			helpers.ExitOnError(err, "")
+		}
+
+		response, err := helpers.ListTechStacks(token, projectsPage, projectsPerPage)
+		if err != nil {
+			helpers.ExitOnError(err, "failed to list projects")
+		}
+
+		if projectsFormat == "json" {
+			outputProjectsJSON(os.Stdout, response)
+			return
+		}
+		outputProjectList(os.Stdout, response)
+	},
+}
+
+var projectsShowCmd = &cobra.Command{
+	Use:   "show <uuid>",
+	Short: "Show a project and its components",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		token, err := helpers.GetToken()
+		if err != nil {
+			helpers.ExitOnError(err, "")
+		}
+
+		response, err := helpers.GetTechStack(token, args[0])
+		if err != nil {
+			helpers.ExitOnError(err, "failed to fetch project")
+		}
+
+		if projectsFormat == "json" {
+			outputProjectsJSON(os.Stdout, response.TechStack)
+			return
+		}
+		outputProject(os.Stdout, response.TechStack)
+	},
+}
+
+var projectsRenameCmd = &cobra.Command{
+	Use:   "rename <uuid> <name>",
+	Short: "Rename a project",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		token, err := helpers.GetToken()
+		if err != nil {
+			helpers.ExitOnError(err, "")
+		}
+
+		response, err := helpers.RenameTechStack(token, args[0], args[1])
+		if err != nil {
+			helpers.ExitOnError(err, "failed to rename project")
+		}
+
+		if projectsFormat == "json" {
+			outputProjectsJSON(os.Stdout, response.TechStack)
+			return
+		}
+		fmt.Printf("✓ Renamed project %s to %s\n", args[0], args[1])
+	},
+}
+
+var projectsDeleteCmd = &cobra.Command{
+	Use:   "delete <uuid>",
+	Short: "Delete a project",
+	Long: `Delete a project and its components from Stack To Date. This cannot be undone,
+so --confirm is required.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if !projectsConfirm {
+			helpers.ExitWithError(2, "refusing to delete project %s without --confirm", args[0])
+		}
+
+		token, err := helpers.GetToken()
+		if err != nil {
+			helpers.ExitOnError(err, "")
+		}
+
+		if err := helpers.DeleteTechStack(token, args[0]); err != nil {
+			helpers.ExitOnError(err, "failed to delete project")
+		}
+
+		if projectsFormat == "json" {
+			outputProjectsJSON(os.Stdout, map[string]interface{}{"id": args[0], "deleted": true})
+			return
+		}
+		fmt.Printf("✓ Deleted project %s\n", args[0])
+	},
+}
+
+// outputProjectList prints a page of projects as a table, with a hint to the next page
+func outputProjectList(out io.Writer, response *helpers.TechStackListResponse) {
+	if len(response.TechStacks) == 0 {
+		fmt.Fprintln(out, "No projects found")
+		return
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "UUID\tNAME\tCOMPONENTS")
+	for _, techStack := range response.TechStacks {
+		fmt.Fprintf(w, "%s\t%s\t%d\n", techStack.ID, techStack.Name, len(techStack.Components))
+	}
+	w.Flush()
+
+	meta := response.Meta
+	if meta.TotalPages > 1 {
+		fmt.Fprintf(out, "\nPage %d of %d (%d projects)", meta.Page, meta.TotalPages, meta.TotalCount)
+		if meta.Page < meta.TotalPages {
+			fmt.Fprintf(out, ", use --page %d for more", meta.Page+1)
+		}
+		fmt.Fprintln(out)
+	}
+}
+
+// outputProject prints a project and its components, sorted by name
+func outputProject(out io.Writer, techStack helpers.TechStack) {
+	fmt.Fprintf(out, "%s (%s)\n\n", techStack.Name, techStack.ID)
+
+	if len(techStack.Components) == 0 {
+		fmt.Fprintln(out, "No components")
+		return
+	}
+
+	components := append([]helpers.Component(nil), techStack.Components...)
+	sort.Slice(components, func(i, j int) bool {
+		if components[i].Name != components[j].Name {
+			return components[i].Name < components[j].Name
+		}
+		return components[i].Version < components[j].Version
+	})
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "COMPONENT\tVERSION")
+	for _, component := range components {
+		fmt.Fprintf(w, "%s\t%s\n", component.Name, component.Version)
+	}
+	w.Flush()
+}
+
+func outputProjectsJSON(w io.Writer, v interface{}) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		helpers.ExitOnError(err, "failed to marshal JSON")
+	}
+	fmt.Fprintln(w, string(data))
+}
+
+func init() {
+	rootCmd.AddCommand(projectsCmd)
+	projectsCmd.AddCommand(projectsListCmd)
+	projectsCmd.AddCommand(projectsShowCmd)
+	projectsCmd.AddCommand(projectsRenameCmd)
+	projectsCmd.AddCommand(projectsDeleteCmd)
+	projectsCmd.PersistentFlags().StringVarP(&projectsFormat, "format", "f", "text", "Output format: text or json (default: text)")
+	projectsListCmd.Flags().IntVar(&projectsPage, "page", 1, "Page of projects to show")
+	projectsListCmd.Flags().IntVar(&projectsPerPage, "per-page", 20, "Number of projects per page")
+	projectsDeleteCmd.Flags().BoolVar(&projectsConfirm, "confirm", false, "Confirm the deletion of the project")
+}
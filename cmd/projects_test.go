@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stacktodate/stacktodate-cli/cmd/helpers"
+)
+
+func TestOutputProjectList(t *testing.T) {
+	response := &helpers.TechStackListResponse{
+		TechStacks: []helpers.TechStack{
+			{ID: "abc", Name: "Web", Components: []helpers.Component{{Name: "go", Version: "1.22"}}},
+			{ID: "def", Name: "API"},
+		},
+		Meta: helpers.Pagination{Page: 1, PerPage: 2, TotalPages: 2, TotalCount: 3},
+	}
+
+	var buf bytes.Buffer
+	outputProjectList(&buf, response)
+
+	expected := `UUID  NAME  COMPONENTS
+abc   Web   1
+def   API   0
+
+Page 1 of 2 (3 projects), use --page 2 for more
+`
+	if buf.String() != expected {
+		t.Errorf("outputProjectList() =\n%s\nwant\n%s", buf.String(), expected)
+	}
+
+	buf.Reset()
+	response.Meta = helpers.Pagination{Page: 1, PerPage: 20, TotalPages: 1, TotalCount: 2}
+	outputProjectList(&buf, response)
+	if bytes.Contains(buf.Bytes(), []byte("Page")) {
+		t.Errorf("outputProjectList() = %q, want no pagination for a single page", buf.String())
+	}
+}
@@ -0,0 +1,394 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/stacktodate/stacktodate-cli/cmd/helpers"
+	"github.com/stacktodate/stacktodate-cli/cmd/lib/versions"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	pullConfigFile string
+	pullPrefer     string
+	pullPrune      bool
+)
+
+// stackChange is a technology that differs between stacktodate.yml and the remote tech
+// stack. Local is empty for technologies only in the remote stack, Remote for
+// technologies only in stacktodate.yml. Remote holds several versions, sorted, when
+// the remote stack lists the technology more than once.
+type stackChange struct {
+	Name   string
+	Local  string
+	Remote []string
+}
+
+// conflict reports whether the change needs --prefer to be resolved; technologies only in
+// the remote stack are added unless it has several versions of them
+func (c stackChange) conflict() bool {
+	return c.Local != "" || len(c.Remote) > 1
+}
+
+// resolution is how a change is applied: the remote version to use, empty to remove the
+// technology, unless the change is skipped to keep stacktodate.yml as it is
+type resolution struct {
+	Apply   bool
+	Version string
+}
+
+var pullCmd = &cobra.Command{
+	Use:   "pull",
+	Short: "Merge the remote tech stack into stacktodate.yml",
+	Long: `Fetch the tech stack of the project from the API and merge its components into the
+stack of stacktodate.yml.
+
+Technologies only in the remote stack are added. Conflicts, where the versions differ, the
+remote stack lists several versions of a technology or, with --prune, a technology is no
+longer in the remote stack, are resolved with --prefer:
+
+  remote       use the remote version (the highest one when there are several), or remove
+               technologies not in the remote stack when --prune is set
+  local        keep stacktodate.yml as it is
+  interactive  ask for each conflict (default)
+
+The source of local entries is kept when their version is updated, and the rest of
+stacktodate.yml, including comments, is left as written.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if pullPrefer != "remote" && pullPrefer != "local" && pullPrefer != "interactive" {
+			helpers.ExitWithError(2, "invalid --prefer %q (expected remote, local or interactive)", pullPrefer)
+		}
+
+		config, err := helpers.LoadConfigWithDefaults(pullConfigFile, true)
+		if err != nil {
+			helpers.ExitOnError(err, "failed to load config")
+		}
+
+		token, err := helpers.GetToken()
+		if err != nil {
+			helpers.ExitOnError(err, "")
+		}
+
+		remote, err := helpers.GetTechStack(token, config.UUID)
+		if err != nil {
+			helpers.ExitOnError(err, "failed to fetch remote tech stack")
+		}
+
+		changes := diffRemoteStack(config, remote.TechStack.Components, pullPrune)
+		if len(changes) == 0 {
+			fmt.Printf("Already up to date with %s\n", remote.TechStack.Name)
+			return
+		}
+
+		fmt.Printf("Pulling tech stack %s (%s)\n", remote.TechStack.Name, config.UUID)
+
+		configPath := pullConfigFile
+		if configPath == "" {
+			configPath = "stacktodate.yml"
+		}
+
+		data, err := os.ReadFile(configPath)
+		if err != nil {
+			helpers.ExitOnError(err, "failed to read config")
+		}
+		var doc yaml.Node
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			helpers.ExitOnError(err, "failed to parse config")
+		}
+
+		// Resolve every conflict before changing anything
+		reader := bufio.NewReader(os.Stdin)
+		resolutions := make([]resolution, len(changes))
+		for i, change := range changes {
+			switch {
+			case !change.conflict():
+				resolutions[i] = resolution{Apply: true, Version: change.Remote[0]}
+			case pullPrefer == "remote":
+				resolutions[i] = preferRemote(change)
+			case pullPrefer == "interactive":
+				resolutions[i], err = promptConflict(reader, change)
+				if err != nil {
+					helpers.ExitWithError(2, "cannot resolve conflicts without a terminal, use --prefer remote or --prefer local")
+				}
+			}
+		}
+
+		fmt.Println()
+		applied := 0
+		for i, change := range changes {
+			printStackChange(os.Stdout, change, resolutions[i])
+			if resolutions[i].Apply {
+				if err := applyRemoteChange(&doc, change.Name, resolutions[i].Version); err != nil {
+					helpers.ExitOnError(err, "failed to update config")
+				}
+				applied++
+			}
+		}
+
+		if applied == 0 {
+			fmt.Println("\nNo changes applied, stacktodate.yml kept as is")
+			return
+		}
+
+		output, err := encodeYAML(&doc, yamlIndent(data))
+		if err != nil {
+			helpers.ExitOnError(err, "failed to create configuration")
+		}
+		if err := os.WriteFile(configPath, output, 0644); err != nil {
+			helpers.ExitOnError(err, "failed to write config")
+		}
+
+		fmt.Printf("\n✓ Applied %d changes to %s\n", applied, configPath)
+	},
+}
+
+// diffRemoteStack compares the stack of the config with the remote components, sorted by name.
+// Remote components that match a sub-project entry were pushed from that sub-project and are
+// not merged into the root stack. Local entries that a remote version satisfies, such as
+// "~> 3.2" for 3.3, are up to date. Technologies missing from the remote stack are only listed,
+// to be removed, when prune is set.
+func diffRemoteStack(config *helpers.Config, remote []helpers.Component, prune bool) []stackChange {
+	fromProjects := make(map[helpers.Component]bool)
+	for _, project := range config.Projects {
+		for _, component := range helpers.ConvertStackToComponents(project.Stack) {
+			fromProjects[component] = true
+		}
+	}
+
+	remoteVersions := make(map[string][]string)
+	for _, component := range remote {
+		if !fromProjects[component] && !slices.Contains(remoteVersions[component.Name], component.Version) {
+			remoteVersions[component.Name] = append(remoteVersions[component.Name], component.Version)
+		}
+	}
+
+	var changes []stackChange
+	for name, remote := range remoteVersions {
+		sortVersions(remote)
+		entry, exists := config.Stack[name]
+		switch {
+		case !exists:
+			changes = append(changes, stackChange{Name: name, Remote: remote})
+		case !slices.ContainsFunc(remote, func(version string) bool { return versionMatches(entry.Version, version) }):
+			changes = append(changes, stackChange{Name: name, Local: entry.Version, Remote: remote})
+		}
+	}
+
+	if prune {
+		for name, entry := range config.Stack {
+			if _, exists := remoteVersions[name]; !exists && !fromProjects[helpers.Component{Name: name, Version: entry.Version}] {
+				changes = append(changes, stackChange{Name: name, Local: entry.Version})
+			}
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Name < changes[j].Name })
+	return changes
+}
+
+// sortVersions sorts distinct version strings from lowest to highest
+func sortVersions(list []string) {
+	sort.Slice(list, func(i, j int) bool {
+		a, errA := versions.Parse(list[i])
+		b, errB := versions.Parse(list[j])
+		if errA != nil || errB != nil || a.Compare(b) == 0 {
+			return list[i] < list[j]
+		}
+		return a.Compare(b) < 0
+	})
+}
+
+// preferRemote resolves a conflict with the remote side: the highest remote version, or
+// removing technologies not in the remote stack
+func preferRemote(change stackChange) resolution {
+	if len(change.Remote) == 0 {
+		return resolution{Apply: true}
+	}
+	return resolution{Apply: true, Version: change.Remote[len(change.Remote)-1]}
+}
+
+// applyRemoteChange sets the version of a technology in the stack of a stacktodate.yml
+// document, or removes it when version is empty. Only the version node changes, so
+// comments, key order, quoting and the source of the entry are kept.
+func applyRemoteChange(doc *yaml.Node, name, version string) error {
+	root := doc
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+	if root.Kind != yaml.MappingNode {
+		return fmt.Errorf("stacktodate.yml is not a mapping")
+	}
+
+	stack := mappingValue(root, "stack")
+	if version == "" {
+		if stack != nil {
+			removeMappingKey(stack, name)
+		}
+		return nil
+	}
+
+	if stack == nil {
+		stack = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		root.Content = append(root.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "stack"}, stack)
+	} else if stack.Kind != yaml.MappingNode {
+		// An empty "stack:" is null
+		*stack = yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Line: stack.Line}
+	}
+
+	entry := mappingValue(stack, name)
+	if entry == nil || entry.Kind != yaml.MappingNode {
+		var node yaml.Node
+		if err := node.Encode(helpers.StackEntry{Version: version}); err != nil {
+			return err
+		}
+		if entry != nil {
+			*entry = node
+		} else {
+			stack.Content = append(stack.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: name}, &node)
+		}
+		return nil
+	}
+
+	value := mappingValue(entry, "version")
+	if value == nil {
+		value = &yaml.Node{Kind: yaml.ScalarNode}
+		entry.Content = append([]*yaml.Node{{Kind: yaml.ScalarNode, Tag: "!!str", Value: "version"}, value}, entry.Content...)
+	}
+	value.Kind, value.Value = yaml.ScalarNode, version
+	if value.Style&(yaml.SingleQuotedStyle|yaml.DoubleQuotedStyle) != 0 {
+		value.Tag = "!!str"
+	} else {
+		// Versions written without quotes stay so, e.g. 3.3; the encoder quotes those that need it
+		value.Tag, value.Style = "", 0
+	}
+	return nil
+}
+
+// mappingValue returns the value of a key in a mapping node, or nil
+func mappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// removeMappingKey removes a key and its value from a mapping node
+func removeMappingKey(mapping *yaml.Node, key string) {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			mapping.Content = append(mapping.Content[:i], mapping.Content[i+2:]...)
+			return
+		}
+	}
+}
+
+// yamlIndent returns the indentation of a YAML file, as the smallest indentation of its
+// lines, or the encoder's default of 4 spaces
+func yamlIndent(data []byte) int {
+	indent := 0
+	for _, line := range strings.Split(string(data), "\n") {
+		trimmed := strings.TrimLeft(line, " ")
+		n := len(line) - len(trimmed)
+		if n == 0 || trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		if indent == 0 || n < indent {
+			indent = n
+		}
+	}
+	if indent < 2 {
+		return 4
+	}
+	return indent
+}
+
+// encodeYAML encodes a YAML document with the given indentation
+func encodeYAML(doc *yaml.Node, indent int) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(indent)
+	if err := encoder.Encode(doc); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// promptConflict asks how to resolve a conflict: keep stacktodate.yml as it is, or use
+// one of the remote versions or remove the technology
+func promptConflict(reader *bufio.Reader, change stackChange) (resolution, error) {
+	options := []resolution{{}}
+	switch {
+	case len(change.Remote) == 0:
+		fmt.Printf("\n%s %s is not in the remote tech stack:\n", change.Name, change.Local)
+		fmt.Printf("  1) Keep %s\n", change.Local)
+		fmt.Printf("  2) Remove it\n")
+		options = append(options, resolution{Apply: true})
+	case len(change.Remote) == 1:
+		fmt.Printf("\n%s differs: local %s, remote %s\n", change.Name, change.Local, change.Remote[0])
+		fmt.Printf("  1) Keep local (%s)\n", change.Local)
+		fmt.Printf("  2) Use remote (%s)\n", change.Remote[0])
+		options = append(options, resolution{Apply: true, Version: change.Remote[0]})
+	default:
+		fmt.Printf("\n%s has several versions in the remote tech stack: %s\n", change.Name, strings.Join(change.Remote, ", "))
+		if change.Local != "" {
+			fmt.Printf("  1) Keep local (%s)\n", change.Local)
+		} else {
+			fmt.Printf("  1) Do not add it\n")
+		}
+		for i, version := range change.Remote {
+			fmt.Printf("  %d) Use %s\n", i+2, version)
+			options = append(options, resolution{Apply: true, Version: version})
+		}
+	}
+
+	for {
+		fmt.Print("Your choice: ")
+		input, err := reader.ReadString('\n')
+		if err != nil && input == "" {
+			return resolution{}, err
+		}
+
+		if choice, err := strconv.Atoi(strings.TrimSpace(input)); err == nil && choice >= 1 && choice <= len(options) {
+			return options[choice-1], nil
+		}
+		fmt.Println("Invalid choice. Please try again.")
+	}
+}
+
+// printStackChange prints a change and how it was resolved
+func printStackChange(w io.Writer, change stackChange, resolved resolution) {
+	switch {
+	case !resolved.Apply && change.Local == "":
+		fmt.Fprintf(w, "  = %-12s not added   (remote has %s)\n", change.Name, strings.Join(change.Remote, ", "))
+	case !resolved.Apply:
+		fmt.Fprintf(w, "  = %-12s %s   (kept local)\n", change.Name, change.Local)
+	case change.Local == "":
+		fmt.Fprintf(w, "  + %-12s %s   (added remotely)\n", change.Name, resolved.Version)
+	case resolved.Version == "":
+		fmt.Fprintf(w, "  - %-12s %s   (not in remote stack, removed)\n", change.Name, change.Local)
+	default:
+		fmt.Fprintf(w, "  ~ %-12s %s → %s   (remote version)\n", change.Name, change.Local, resolved.Version)
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(pullCmd)
+	pullCmd.Flags().StringVarP(&pullConfigFile, "config", "c", "", "Path to stacktodate.yml config file (default: stacktodate.yml)")
+	pullCmd.Flags().StringVar(&pullPrefer, "prefer", "interactive", "Resolve conflicts with the remote or local version, or interactively: remote, local or interactive")
+	pullCmd.Flags().BoolVar(&pullPrune, "prune", false, "Also remove technologies that are no longer in the remote tech stack")
+}
@@ -0,0 +1,214 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stacktodate/stacktodate-cli/cmd/helpers"
+	"gopkg.in/yaml.v3"
+)
+
+func TestDiffRemoteStack(t *testing.T) {
+	config := &helpers.Config{
+		Stack: map[string]helpers.StackEntry{
+			"go":     {Version: "1.22", Source: "go.mod"},
+			"ruby":   {Version: "3.3", Source: ".ruby-version"},
+			"python": {Version: "3.11", Source: ".python-version"},
+			"php":    {Version: "8.2", Source: "composer.json"},
+			"rails":  {Version: "~> 7.1", Source: "Gemfile"},
+			"java":   {Version: "17", Source: ".java-version"},
+		},
+		Projects: map[string]helpers.ProjectConfig{
+			"apps/web": {Stack: map[string]helpers.StackEntry{"nodejs": {Version: "20", Source: ".nvmrc"}}},
+		},
+	}
+	remote := []helpers.Component{
+		{Name: "go", Version: "1.21"},
+		{Name: "python", Version: "3.11"},
+		{Name: "rails", Version: "7.2"},
+		{Name: "kotlin", Version: "2.0"},
+		{Name: "kotlin", Version: "1.9"},
+		{Name: "dotnet", Version: "8.0"},
+		{Name: "nodejs", Version: "20"}, // Pushed from apps/web
+		{Name: "php", Version: "8.3"},
+		{Name: "php", Version: "8.10"},
+		{Name: "java", Version: "21"},
+		{Name: "java", Version: "17"},
+		{Name: "java", Version: "21"},
+	}
+
+	changes := diffRemoteStack(config, remote, true)
+
+	// rails ~> 7.1 matches 7.2 and java 17 is one of the remote versions
+	expected := []stackChange{
+		{Name: "dotnet", Remote: []string{"8.0"}},
+		{Name: "go", Local: "1.22", Remote: []string{"1.21"}},
+		{Name: "kotlin", Remote: []string{"1.9", "2.0"}},
+		{Name: "php", Local: "8.2", Remote: []string{"8.3", "8.10"}},
+		{Name: "ruby", Local: "3.3"},
+	}
+	if !reflect.DeepEqual(changes, expected) {
+		t.Errorf("diffRemoteStack() = %+v, want %+v", changes, expected)
+	}
+
+	// Several remote versions are a conflict even for technologies only in the remote stack
+	conflicts := map[string]bool{"dotnet": false, "go": true, "kotlin": true, "php": true, "ruby": true}
+	for _, change := range changes {
+		if change.conflict() != conflicts[change.Name] {
+			t.Errorf("%s: conflict() = %v, want %v", change.Name, change.conflict(), conflicts[change.Name])
+		}
+	}
+
+	// Without --prune, technologies missing from the remote stack are kept
+	if changes := diffRemoteStack(config, remote, false); len(changes) != 4 || changes[3].Name != "php" {
+		t.Errorf("diffRemoteStack() without prune = %+v, want no removals", changes)
+	}
+}
+
+func TestPullEmptyRemotePreferRemote(t *testing.T) {
+	config := &helpers.Config{
+		Stack: map[string]helpers.StackEntry{
+			"go":   {Version: "1.22", Source: "go.mod"},
+			"ruby": {Version: "3.3", Source: ".ruby-version"},
+		},
+	}
+
+	// An empty remote stack leaves stacktodate.yml as it is
+	if changes := diffRemoteStack(config, nil, false); len(changes) != 0 {
+		t.Errorf("diffRemoteStack() of an empty remote = %+v, want no changes", changes)
+	}
+
+	// With --prune and --prefer remote, every technology is removed
+	changes := diffRemoteStack(config, nil, true)
+	if len(changes) != 2 {
+		t.Fatalf("diffRemoteStack() with prune = %+v, want 2 removals", changes)
+	}
+	for _, change := range changes {
+		if resolved := preferRemote(change); resolved != (resolution{Apply: true}) {
+			t.Errorf("preferRemote(%+v) = %+v, want removal", change, resolved)
+		}
+	}
+}
+
+func TestPreferRemote(t *testing.T) {
+	tests := []struct {
+		change   stackChange
+		expected resolution
+	}{
+		{stackChange{Name: "go", Local: "1.22", Remote: []string{"1.21"}}, resolution{Apply: true, Version: "1.21"}},
+		{stackChange{Name: "java", Remote: []string{"17", "21"}}, resolution{Apply: true, Version: "21"}},
+		{stackChange{Name: "ruby", Local: "3.3"}, resolution{Apply: true}},
+	}
+
+	for _, tt := range tests {
+		if result := preferRemote(tt.change); result != tt.expected {
+			t.Errorf("preferRemote(%+v) = %+v, want %+v", tt.change, result, tt.expected)
+		}
+	}
+}
+
+func TestApplyRemoteChange(t *testing.T) {
+	input := `# Tech stack of the API
+uuid: 4f8e9c2a
+name: API
+
+stack:
+  go:
+    version: 1.22 # pinned by the platform team
+    source: go.mod
+  ruby:
+    version: "3.3"
+    source: .ruby-version
+  python:
+    version: '3.11'
+    source: .python-version
+
+projects:
+  apps/web:
+    stack:
+      nodejs:
+        version: "20"
+        source: .nvmrc
+`
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(input), &doc); err != nil {
+		t.Fatalf("yaml.Unmarshal failed: %v", err)
+	}
+
+	for _, change := range []struct{ name, version string }{
+		{"go", "1.21"},
+		{"python", ">=3.12"},
+		{"ruby", ""},
+		{"rails", "7.1"},
+	} {
+		if err := applyRemoteChange(&doc, change.name, change.version); err != nil {
+			t.Fatalf("applyRemoteChange(%s) failed: %v", change.name, err)
+		}
+	}
+
+	output, err := encodeYAML(&doc, yamlIndent([]byte(input)))
+	if err != nil {
+		t.Fatalf("encodeYAML failed: %v", err)
+	}
+
+	expected := `# Tech stack of the API
+uuid: 4f8e9c2a
+name: API
+stack:
+  go:
+    version: 1.21 # pinned by the platform team
+    source: go.mod
+  python:
+    version: '>=3.12'
+    source: .python-version
+  rails:
+    version: "7.1"
+    source: ""
+projects:
+  apps/web:
+    stack:
+      nodejs:
+        version: "20"
+        source: .nvmrc
+`
+	if string(output) != expected {
+		t.Errorf("applyRemoteChange: got\n%s\nwant\n%s", output, expected)
+	}
+}
+
+func TestApplyRemoteChangeWithoutStack(t *testing.T) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte("uuid: 4f8e9c2a\nname: API\nstack:\n"), &doc); err != nil {
+		t.Fatalf("yaml.Unmarshal failed: %v", err)
+	}
+
+	if err := applyRemoteChange(&doc, "go", "1.22"); err != nil {
+		t.Fatalf("applyRemoteChange failed: %v", err)
+	}
+
+	var config helpers.Config
+	if err := doc.Decode(&config); err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+	if config.Stack["go"].Version != "1.22" {
+		t.Errorf("applyRemoteChange: stack = %+v, want go 1.22", config.Stack)
+	}
+}
+
+func TestYAMLIndent(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int
+	}{
+		{"stack:\n  go:\n    version: \"1.22\"\n", 2},
+		{"stack:\n    go:\n        version: \"1.22\"\n", 4},
+		{"# comment\nuuid: abc\n", 4},
+	}
+
+	for _, tt := range tests {
+		if indent := yamlIndent([]byte(tt.input)); indent != tt.expected {
+			t.Errorf("yamlIndent(%q) = %d, want %d", tt.input, indent, tt.expected)
+		}
+	}
+}
@@ -1,39 +1,41 @@
 package cmd
 
 import (
-	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
-	"net/http"
+	"os"
 
 	"github.com/stacktodate/stacktodate-cli/cmd/helpers"
-	"github.com/stacktodate/stacktodate-cli/cmd/lib/cache"
 	"github.com/spf13/cobra"
 )
 
 var (
 	configFile string
+	pushDryRun bool
+	pushFormat string
 )
 
-type PushRequest struct {
-	Components []helpers.Component `json:"components"`
-}
-
-type PushResponse struct {
-	Success   bool   `json:"success"`
-	Message   string `json:"message"`
-	TechStack struct {
-		ID         string               `json:"id"`
-		Name       string               `json:"name"`
-		Components []helpers.Component  `json:"components"`
-	} `json:"tech_stack"`
+// PushResult is the JSON output of push
+type PushResult struct {
+	UUID   string                `json:"uuid"`
+	Name   string                `json:"name"`
+	DryRun bool                  `json:"dry_run"`
+	Pushed bool                  `json:"pushed"`
+	Diff   helpers.ComponentDiff `json:"diff"`
 }
 
 var pushCmd = &cobra.Command{
 	Use:   "push",
 	Short: "Push tech stack components to the API",
-	Long:  `Push the components defined in stacktodate.yml to the remote API`,
+	Long: `Push the components defined in stacktodate.yml to the remote API
+
+Before pushing, the components are compared with the remote tech stack and the added,
+removed and changed components are printed. With --dry-run, only the changes are printed
+and nothing is pushed. --format json prints the changes as JSON, e.g. for bots.
+
+Exits with code 3 when the token is rejected, 4 when the project does not exist,
+5 when the API rejects the components and 6 when the API is unavailable.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		// Load config with UUID validation
 		config, err := helpers.LoadConfigWithDefaults(configFile, true)
@@ -47,79 +49,76 @@ var pushCmd = &cobra.Command{
 			helpers.ExitOnError(err, "")
 		}
 
-		// Get API URL from environment or use default
-		apiURL := cache.GetAPIURL()
-
 		// Convert stack to components
-		components := helpers.ConvertStackToComponents(config.Stack)
+		components := helpers.ConvertConfigToComponents(config)
 
-		// Create request
-		request := PushRequest{
-			Components: components,
+		// Compare with the remote tech stack
+		remote, err := helpers.GetTechStack(token, config.UUID)
+		if err != nil {
+			helpers.ExitOnError(err, "failed to fetch remote tech stack")
 		}
 
-		// Make API call
-		if err := pushToAPI(apiURL, config.UUID, token, request); err != nil {
-			helpers.ExitOnError(err, "failed to push to API")
+		result := PushResult{
+			UUID:   config.UUID,
+			Name:   remote.TechStack.Name,
+			DryRun: pushDryRun,
+			Diff:   helpers.DiffComponents(remote.TechStack.Components, components),
 		}
 
-		fmt.Printf("✓ Successfully pushed %d components\n", len(components))
-	},
-}
-
-func pushToAPI(apiURL, techStackID, token string, request PushRequest) error {
-	// Build URL
-	url := fmt.Sprintf("%s/api/tech_stacks/%s/components", apiURL, techStackID)
-
-	// Marshal request to JSON
-	requestBody, err := json.Marshal(request)
-	if err != nil {
-		return fmt.Errorf("failed to marshal request: %w", err)
-	}
+		if !pushDryRun {
+			if _, err := helpers.PushComponents(token, config.UUID, components); err != nil {
+				helpers.ExitOnError(err, "failed to push to API")
+			}
+			result.Pushed = true
+		}
 
-	// Create HTTP request
-	req, err := http.NewRequest("PUT", url, bytes.NewBuffer(requestBody))
-	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
-	}
+		if pushFormat == "json" {
+			outputPushJSON(os.Stdout, result)
+			return
+		}
 
-	// Set headers
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+		outputPushDiff(os.Stdout, result)
+		if pushDryRun {
+			fmt.Println("Dry run: nothing was pushed")
+		} else {
+			fmt.Printf("✓ Successfully pushed %d components\n", len(components))
+		}
+	},
+}
 
-	// Make request
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return fmt.Errorf("failed to make request: %w", err)
+// outputPushDiff prints the changes push makes to the remote tech stack
+func outputPushDiff(w io.Writer, result PushResult) {
+	diff := result.Diff
+	if diff.Empty() {
+		fmt.Fprintf(w, "No changes to %s (%d components up to date)\n", result.Name, diff.Unchanged)
+		return
 	}
-	defer resp.Body.Close()
 
-	// Read response body
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return fmt.Errorf("failed to read response: %w", err)
+	fmt.Fprintf(w, "Changes to %s:\n", result.Name)
+	for _, component := range diff.Added {
+		fmt.Fprintf(w, "  + %-12s %s\n", component.Name, component.Version)
 	}
-
-	// Handle error responses
-	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
-		return fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(body))
+	for _, component := range diff.Removed {
+		fmt.Fprintf(w, "  - %-12s %s\n", component.Name, component.Version)
 	}
-
-	// Parse response
-	var response PushResponse
-	if err := json.Unmarshal(body, &response); err != nil {
-		return fmt.Errorf("failed to parse response: %w", err)
+	for _, change := range diff.Changed {
+		fmt.Fprintf(w, "  ~ %-12s %s → %s\n", change.Name, change.From, change.To)
 	}
+	fmt.Fprintf(w, "%d added, %d removed, %d changed, %d unchanged\n",
+		len(diff.Added), len(diff.Removed), len(diff.Changed), diff.Unchanged)
+}
 
-	if !response.Success {
-		return fmt.Errorf("API returned success=false: %s", response.Message)
+func outputPushJSON(w io.Writer, result PushResult) {
+	data, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		helpers.ExitOnError(err, "failed to marshal JSON")
 	}
-
-	return nil
+	fmt.Fprintln(w, string(data))
 }
 
 func init() {
 	rootCmd.AddCommand(pushCmd)
 	pushCmd.Flags().StringVarP(&configFile, "config", "c", "", "Path to stacktodate.yml config file (default: stacktodate.yml)")
+	pushCmd.Flags().BoolVar(&pushDryRun, "dry-run", false, "Show the changes to the remote tech stack without pushing")
+	pushCmd.Flags().StringVarP(&pushFormat, "format", "f", "text", "Output format: text or json (default: text)")
 }
@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stacktodate/stacktodate-cli/cmd/helpers"
+)
+
+func TestOutputPushDiff(t *testing.T) {
+	result := PushResult{
+		Name: "Web",
+		Diff: helpers.DiffComponents(
+			[]helpers.Component{{Name: "go", Version: "1.21"}, {Name: "python", Version: "3.9"}, {Name: "ruby", Version: "3.3"}},
+			[]helpers.Component{{Name: "go", Version: "1.22"}, {Name: "rails", Version: "7.1"}, {Name: "ruby", Version: "3.3"}},
+		),
+	}
+
+	var buf bytes.Buffer
+	outputPushDiff(&buf, result)
+
+	expected := `Changes to Web:
+  + rails        7.1
+  - python       3.9
+  ~ go           1.21 → 1.22
+1 added, 1 removed, 1 changed, 1 unchanged
+`
+	if buf.String() != expected {
+		t.Errorf("outputPushDiff() =\n%s\nwant\n%s", buf.String(), expected)
+	}
+
+	buf.Reset()
+	result.Diff = helpers.DiffComponents(nil, nil)
+	outputPushDiff(&buf, result)
+	if buf.String() != "No changes to Web (0 components up to date)\n" {
+		t.Errorf("outputPushDiff() = %q, want no changes", buf.String())
+	}
+}
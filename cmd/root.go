@@ -3,19 +3,34 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"sync"
+	"time"
 
 	"github.com/stacktodate/stacktodate-cli/cmd/globalconfig"
 	"github.com/stacktodate/stacktodate-cli/cmd/helpers"
+	"github.com/stacktodate/stacktodate-cli/cmd/lib/cache"
 	"github.com/stacktodate/stacktodate-cli/cmd/lib/versioncheck"
 	"github.com/stacktodate/stacktodate-cli/internal/version"
 	"github.com/spf13/cobra"
 )
 
+var catalogTTL string
+
 var rootCmd = &cobra.Command{
 	Use:   "stacktodate",
 	Short: "Official CLI for Stack To Date",
 	Long:  `stacktodate - Track technology lifecycle statuses and plan for end-of-life upgrades`,
 	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		// Settings are resolved when first used, so that invalid ones only fail the
+		// commands that read the catalog or make requests
+		cache.SetTTLFunc(sync.OnceValues(func() (time.Duration, error) {
+			return resolveCatalogTTL(catalogTTL)
+		}))
+
+		client := helpers.HTTPClient()
+		cache.SetHTTPClient(client)
+		versioncheck.SetHTTPClient(client)
+
 		// Only check on specific commands that should trigger automatic checks
 		cmdName := cmd.Name()
 		if shouldAutoCheck(cmdName) {
@@ -40,12 +55,35 @@ func Execute() {
 
 func init() {
 	rootCmd.Flags().BoolP("version", "v", false, "Print the version number")
+	rootCmd.PersistentFlags().StringVar(&catalogTTL, "catalog-ttl", "", "How long to use the cached product catalog before revalidating it, e.g. 12h or 7d (default 24h)")
 	rootCmd.AddCommand(initCmd)
 	rootCmd.AddCommand(versionCmd)
 	rootCmd.AddCommand(autodetectCmd)
 	rootCmd.AddCommand(globalconfig.GlobalConfigCmd)
 }
 
+// resolveCatalogTTL returns the product catalog TTL from the --catalog-ttl flag, the
+// STD_CATALOG_TTL environment variable or the global config file, in that order
+func resolveCatalogTTL(flag string) (time.Duration, error) {
+	if flag != "" {
+		return helpers.ParseTTL(flag)
+	}
+
+	if value := os.Getenv("STD_CATALOG_TTL"); value != "" {
+		return helpers.ParseTTL(value)
+	}
+
+	settings, err := helpers.LoadGlobalSettings()
+	if err != nil {
+		return 0, err
+	}
+	if settings.CatalogTTL != "" {
+		return helpers.ParseTTL(settings.CatalogTTL)
+	}
+
+	return cache.DefaultTTL, nil
+}
+
 // shouldAutoCheck determines if a command should trigger automatic version checks
 func shouldAutoCheck(cmdName string) bool {
 	// Commands that should trigger automatic version checks
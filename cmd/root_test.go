@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stacktodate/stacktodate-cli/cmd/lib/cache"
+)
+
+func TestResolveCatalogTTL(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("STD_CATALOG_TTL", "")
+
+	ttl, err := resolveCatalogTTL("")
+	if err != nil || ttl != cache.DefaultTTL {
+		t.Errorf("resolveCatalogTTL: expected default TTL, got %v, %v", ttl, err)
+	}
+
+	// Global config file
+	if err := os.MkdirAll(filepath.Join(home, ".stacktodate"), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(home, ".stacktodate", "config.yaml"), []byte("catalog_ttl: 7d\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if ttl, _ := resolveCatalogTTL(""); ttl != 7*24*time.Hour {
+		t.Errorf("resolveCatalogTTL: expected 7 days from global config, got %v", ttl)
+	}
+
+	// Environment variable over global config
+	t.Setenv("STD_CATALOG_TTL", "12h")
+	if ttl, _ := resolveCatalogTTL(""); ttl != 12*time.Hour {
+		t.Errorf("resolveCatalogTTL: expected 12h from environment, got %v", ttl)
+	}
+
+	// Flag over environment variable
+	if ttl, _ := resolveCatalogTTL("30m"); ttl != 30*time.Minute {
+		t.Errorf("resolveCatalogTTL: expected 30m from flag, got %v", ttl)
+	}
+
+	if _, err := resolveCatalogTTL("soon"); err == nil {
+		t.Errorf("resolveCatalogTTL: expected error for invalid TTL")
+	}
+}
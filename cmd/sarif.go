@@ -0,0 +1,181 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/stacktodate/stacktodate-cli/cmd/lib/gitfs"
+	"github.com/stacktodate/stacktodate-cli/internal/version"
+)
+
+const sarifSchema = "https://json.schemastore.org/sarif-2.1.0.json"
+
+// sarifLog is the subset of the SARIF 2.1.0 format written by check
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string             `json:"id"`
+	ShortDescription     sarifMessage       `json:"shortDescription"`
+	DefaultConfiguration sarifConfiguration `json:"defaultConfiguration"`
+}
+
+type sarifConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI       string `json:"uri"`
+	URIBaseID string `json:"uriBaseId"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+// sarifRules describes every rule a check result can report
+var sarifRules = []sarifRule{
+	{ID: ruleVersionMismatch, ShortDescription: sarifMessage{Text: "Detected version differs from stacktodate.yml"}, DefaultConfiguration: sarifConfiguration{Level: severityError}},
+	{ID: ruleMissing, ShortDescription: sarifMessage{Text: "Technology in stacktodate.yml was not detected"}, DefaultConfiguration: sarifConfiguration{Level: severityError}},
+	{ID: ruleUntracked, ShortDescription: sarifMessage{Text: "Detected technology is not listed in stacktodate.yml"}, DefaultConfiguration: sarifConfiguration{Level: severityWarning}},
+	{ID: ruleEOL, ShortDescription: sarifMessage{Text: "Tracked version is past its end of life"}, DefaultConfiguration: sarifConfiguration{Level: severityWarning}},
+	{ID: ruleEOLSoon, ShortDescription: sarifMessage{Text: "Tracked version reaches its end of life soon"}, DefaultConfiguration: sarifConfiguration{Level: severityWarning}},
+}
+
+// buildSARIF converts the findings of a check result into a SARIF log. Matched entries
+// are not findings and are left out. Paths are made relative to the repository root
+// by prefixing them with root, the config directory relative to it.
+func buildSARIF(result CheckResult, root, configFile string) sarifLog {
+	results := []sarifResult{}
+
+	for _, entry := range result.Results.Mismatched {
+		// Sub-project technologies are prefixed with their directory, e.g. "apps/web/nodejs"
+		file := path.Join(root, path.Dir(entry.Name), entry.Source)
+		results = append(results, sarifResult{
+			RuleID:    entry.RuleID,
+			Level:     entry.Severity,
+			Message:   sarifMessage{Text: fmt.Sprintf("%s is %s in %s but stacktodate.yml has %s", entry.Name, entry.Detected, entry.Source, entry.Version)},
+			Locations: sarifLocations(file, entry.Line),
+		})
+	}
+
+	for _, entry := range result.Results.MissingConfig {
+		results = append(results, sarifResult{
+			RuleID:    entry.RuleID,
+			Level:     entry.Severity,
+			Message:   sarifMessage{Text: fmt.Sprintf("%s %s is listed in stacktodate.yml but was not detected", entry.Name, entry.Version)},
+			Locations: sarifLocations(path.Join(root, configFile), entry.Line),
+		})
+	}
+
+	for _, entry := range result.Results.Untracked {
+		file := path.Join(root, path.Dir(entry.Name), entry.Source)
+		results = append(results, sarifResult{
+			RuleID:    entry.RuleID,
+			Level:     entry.Severity,
+			Message:   sarifMessage{Text: fmt.Sprintf("%s %s is detected in %s but not listed in stacktodate.yml", entry.Name, entry.Detected, entry.Source)},
+			Locations: sarifLocations(file, entry.Line),
+		})
+	}
+
+	for _, entry := range result.Results.EOL {
+		results = append(results, sarifResult{
+			RuleID:    entry.RuleID,
+			Level:     entry.Severity,
+			Message:   sarifMessage{Text: fmt.Sprintf("%s %s %s", entry.Name, entry.Version, describeEOL(entry))},
+			Locations: sarifLocations(path.Join(root, configFile), entry.Line),
+		})
+	}
+
+	return sarifLog{
+		Schema:  sarifSchema,
+		Version: "2.1.0",
+		Runs: []sarifRun{{
+			Tool: sarifTool{Driver: sarifDriver{
+				Name:           "stacktodate",
+				Version:        version.GetVersion(),
+				InformationURI: "https://stacktodate.club",
+				Rules:          sarifRules,
+			}},
+			Results: results,
+		}},
+	}
+}
+
+// sarifLocations returns the location of a finding, with a region only when the line is known
+func sarifLocations(file string, line int) []sarifLocation {
+	location := sarifLocation{PhysicalLocation: sarifPhysicalLocation{
+		ArtifactLocation: sarifArtifactLocation{URI: file, URIBaseID: "%SRCROOT%"},
+	}}
+	if line > 0 {
+		location.PhysicalLocation.Region = &sarifRegion{StartLine: line}
+	}
+	return []sarifLocation{location}
+}
+
+// sarifRoot returns dir relative to the root of its git repository, so that
+// code-scanning tools can map findings to repository files, or "" outside a repository
+func sarifRoot(dir string) string {
+	repo, err := gitfs.Open(dir)
+	if err != nil {
+		return ""
+	}
+
+	rel, err := filepath.Rel(repo.WorkTree(), dir)
+	if err != nil || rel == "." {
+		return ""
+	}
+	return filepath.ToSlash(rel)
+}
+
+func outputSARIF(w io.Writer, result CheckResult, root, configFile string) {
+	data, err := json.MarshalIndent(buildSARIF(result, root, configFile), "", "  ")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error marshaling SARIF: %v\n", err)
+		os.Exit(2)
+	}
+	fmt.Fprintln(w, string(data))
+}
@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"encoding/json"
+	"sort"
+	"testing"
+
+	"github.com/stacktodate/stacktodate-cli/cmd/helpers"
+)
+
+func TestBuildSARIF(t *testing.T) {
+	days := -30
+	result := CheckResult{
+		Status: "mismatch",
+		Results: CheckResults{
+			Matched: []ComparisonEntry{
+				{Name: "ruby", Version: "3.3", Detected: "3.3", Source: ".ruby-version", Line: 1, RuleID: ruleVersionMatch, Severity: severityNone},
+			},
+			Mismatched: []ComparisonEntry{
+				{Name: "apps/web/nodejs", Version: "18", Detected: "20", Source: ".nvmrc", Line: 1, RuleID: ruleVersionMismatch, Severity: severityError},
+			},
+			MissingConfig: []ComparisonEntry{
+				{Name: "python", Version: "3.11", Source: ".python-version", Line: 9, RuleID: ruleMissing, Severity: severityError},
+			},
+			EOL: []EOLEntry{
+				{Name: "go", Version: "1.21", Status: "eol", EOLDate: "2024-08-13", DaysRemaining: &days, Line: 4, RuleID: ruleEOL, Severity: severityWarning},
+			},
+		},
+	}
+
+	log := buildSARIF(result, "services/api", "stacktodate.yml")
+
+	if log.Version != "2.1.0" || len(log.Runs) != 1 {
+		t.Fatalf("buildSARIF: expected a single SARIF 2.1.0 run, got %+v", log)
+	}
+
+	expected := []struct {
+		ruleID string
+		level  string
+		uri    string
+		line   int
+	}{
+		{ruleVersionMismatch, severityError, "services/api/apps/web/.nvmrc", 1},
+		{ruleMissing, severityError, "services/api/stacktodate.yml", 9},
+		{ruleEOL, severityWarning, "services/api/stacktodate.yml", 4},
+	}
+
+	results := log.Runs[0].Results
+	if len(results) != len(expected) {
+		t.Fatalf("buildSARIF: expected %d results, got %d: %+v", len(expected), len(results), results)
+	}
+	for i, e := range expected {
+		r := results[i]
+		if r.RuleID != e.ruleID || r.Level != e.level {
+			t.Errorf("buildSARIF: expected %s/%s at index %d, got %s/%s", e.ruleID, e.level, i, r.RuleID, r.Level)
+		}
+		location := r.Locations[0].PhysicalLocation
+		if location.ArtifactLocation.URI != e.uri {
+			t.Errorf("buildSARIF: expected uri %s at index %d, got %s", e.uri, i, location.ArtifactLocation.URI)
+		}
+		if location.Region == nil || location.Region.StartLine != e.line {
+			t.Errorf("buildSARIF: expected line %d at index %d, got %+v", e.line, i, location.Region)
+		}
+	}
+}
+
+func TestSARIFLocationsWithoutLine(t *testing.T) {
+	locations := sarifLocations("go.mod", 0)
+
+	if locations[0].PhysicalLocation.Region != nil {
+		t.Errorf("sarifLocations: expected no region for unknown line, got %+v", locations[0].PhysicalLocation.Region)
+	}
+}
+
+func TestBuildSARIFDeterministic(t *testing.T) {
+	configStack := map[string]helpers.StackEntry{}
+	detectedStack := map[string]helpers.StackEntry{}
+	for _, name := range []string{"ruby", "nodejs", "python", "go", "php", "java", "dotnet", "rails"} {
+		configStack[name] = helpers.StackEntry{Version: "1", Source: "stacktodate.yml"}
+		configStack["apps/web/"+name] = helpers.StackEntry{Version: "1", Source: "stacktodate.yml"}
+		detectedStack[name] = helpers.StackEntry{Version: "2", Source: "." + name + "-version"}
+		detectedStack["apps/api/"+name] = helpers.StackEntry{Version: "2", Source: "." + name + "-version"}
+	}
+
+	var first []byte
+	for i := 0; i < 20; i++ {
+		result := compareStacks(configStack, detectedStack)
+		data, err := json.Marshal(buildSARIF(result, "", "stacktodate.yml"))
+		if err != nil {
+			t.Fatalf("json.Marshal failed: %v", err)
+		}
+
+		if i == 0 {
+			first = data
+			for _, entries := range [][]ComparisonEntry{result.Results.Mismatched, result.Results.MissingConfig, result.Results.Untracked} {
+				if !sort.SliceIsSorted(entries, func(i, j int) bool { return entries[i].Name < entries[j].Name }) {
+					t.Errorf("compareStacks: expected entries sorted by name, got %+v", entries)
+				}
+			}
+			continue
+		}
+		if string(data) != string(first) {
+			t.Fatalf("buildSARIF: output differs between runs:\n%s\n%s", first, data)
+		}
+	}
+}
@@ -46,15 +46,24 @@ var updateCmd = &cobra.Command{
 
         var detectedTechs map[string]helpers.StackEntry
         if !skipAutodetect {
-            err = helpers.WithWorkingDir(targetDir, func() error {
-                info := DetectProjectInfo()
-                PrintDetectedInfo(info)
-                detectedTechs = selectCandidates(reader, info)
-                return nil
-            })
+            fsys, err := helpers.OpenDir(targetDir)
+            if err != nil {
+                helpers.ExitOnError(err, "failed to detect project")
+            }
+
+            info, projectInfos, err := DetectConfigProjects(fsys, config.Projects)
             if err != nil {
                 helpers.ExitOnError(err, "failed to detect project")
             }
+            PrintDetectedInfo(info)
+            detectedTechs = selectCandidates(reader, info)
+
+            // Re-detect every sub-project listed in the config
+            for path, info := range projectInfos {
+                fmt.Printf("\n### Project: %s\n", path)
+                PrintDetectedInfo(info)
+                config.Projects[path] = helpers.ProjectConfig{Stack: selectCandidates(reader, info)}
+            }
         } else {
             // If autodetect is skipped, keep existing stack
             detectedTechs = config.Stack